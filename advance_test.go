@@ -0,0 +1,114 @@
+package xorshift
+
+import (
+	"math/big"
+	"testing"
+)
+
+// jumpLog2 holds, for the generators with Jump and LongJump functions, the base 2 log of the jump distances.
+var jumpLog2 = map[string][2]uint{
+	"xorshift64*":          {32, 0},
+	"xorshift128+":         {64, 0},
+	"xoroshiro128+":        {64, 96},
+	"xoroshiro128+ (2016)": {64, 96},
+	"xoroshiro128**":       {64, 96},
+	"xoroshiro256+":        {128, 192},
+	"xoroshiro256++":       {128, 192},
+	"xoroshiro256**":       {128, 192},
+	"xoroshiro512+":        {256, 384},
+	"xoroshiro512**":       {256, 384},
+	"xorshift1024*":        {512, 0},
+	"xorshift1024*phi":     {512, 0},
+	"xorshift4096*":        {2048, 0},
+}
+
+// sameOutput reports whether a and b generate the same first n values.
+func sameOutput(a, b XorShift, n int) bool {
+	for i := 0; i < n; i++ {
+		if a.Uint64() != b.Uint64() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAdvance(t *testing.T) {
+	for _, c := range conformanceCases {
+		if _, ok := c.load(c.state).(XorShiftAdvancer); !ok {
+			if c.name != "splitmix64" {
+				t.Errorf("%s: does not implement XorShiftAdvancer", c.name)
+			}
+			continue
+		}
+
+		for _, n := range []uint64{0, 1, 7, 1000, 5000} {
+			a := c.load(c.state).(XorShiftAdvancer)
+			b := c.load(c.state)
+			a.Advance(n)
+			for i := uint64(0); i < n; i++ {
+				b.Uint64()
+			}
+			if !sameOutput(a, b, 8) {
+				t.Errorf("%s: Advance(%d) differs from %d calls to Uint64()", c.name, n, n)
+			}
+		}
+
+		a := c.load(c.state).(XorShiftAdvancer)
+		a.Advance(12345)
+		a.AdvanceBig(big.NewInt(-12345))
+		if !sameOutput(a, c.load(c.state), 8) {
+			t.Errorf("%s: AdvanceBig(-n) doesn't undo Advance(n)", c.name)
+		}
+	}
+}
+
+func TestAdvanceJump(t *testing.T) {
+	for _, c := range conformanceCases {
+		exp, ok := jumpLog2[c.name]
+		if !ok {
+			continue
+		}
+
+		a := c.load(c.state).(XorShiftAdvancer)
+		b := c.load(c.state).(XorShiftExt)
+		a.AdvanceBig(new(big.Int).Lsh(big.NewInt(1), exp[0]))
+		b.Jump()
+		if !sameOutput(a, b, 8) {
+			t.Errorf("%s: AdvanceBig(2^%d) differs from Jump()", c.name, exp[0])
+		}
+
+		if exp[1] == 0 {
+			continue
+		}
+		a = c.load(c.state).(XorShiftAdvancer)
+		bl := c.load(c.state).(XorShiftLongJumper)
+		a.AdvanceBig(new(big.Int).Lsh(big.NewInt(1), exp[1]))
+		bl.LongJump()
+		if !sameOutput(a, bl, 8) {
+			t.Errorf("%s: AdvanceBig(2^%d) differs from LongJump()", c.name, exp[1])
+		}
+	}
+}
+
+func TestJumpN(t *testing.T) {
+	for _, c := range conformanceCases {
+		if _, ok := c.load(c.state).(XorShiftExt); !ok {
+			continue
+		}
+		if _, ok := c.load(c.state).(XorShiftStreamJumper); !ok {
+			t.Errorf("%s: does not implement XorShiftStreamJumper", c.name)
+			continue
+		}
+		for _, n := range []uint64{0, 1, 3, 16, 37} {
+			a := c.load(c.state).(XorShiftStreamJumper)
+			b := c.load(c.state).(XorShiftExt)
+			a.JumpN(n)
+			for i := uint64(0); i < n; i++ {
+				b.Jump()
+			}
+			if !sameOutput(a, b, 8) {
+				t.Errorf("%s: JumpN(%d) differs from %d calls to Jump()", c.name, n, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package xorshift
+
+import (
+	"math"
+	"math/bits"
+)
+
+// The functions below generate unbiased integers in [0, n) with Lemire's nearly divisionless method
+// ("Fast Random Integer Generation in an Interval", ACM TOMACS, 2019): the random word is multiplied
+// by n, the upper half of the product is the result, and the lower half is used to reject the few
+// values that would make the result biased. A division is needed only when the lower half is less than n,
+// i.e. almost never for small n. They use the upper bits of the generated values, the strongest ones
+// for the "+" generators.
+
+// Uint64n returns an unbiased pseudo random number in [0, n) generated by x. It panics if n == 0.
+func Uint64n(x XorShift, n uint64) uint64 {
+	if n == 0 {
+		panic("xorshift: invalid argument to Uint64n")
+	}
+	hi, lo := bits.Mul64(x.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(x.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// Uint32n returns an unbiased pseudo random number in [0, n) generated by x. It panics if n == 0.
+func Uint32n(x XorShift, n uint32) uint32 {
+	if n == 0 {
+		panic("xorshift: invalid argument to Uint32n")
+	}
+	p := (x.Uint64() >> 32) * uint64(n)
+	if uint32(p) < n {
+		thresh := -n % n
+		for uint32(p) < thresh {
+			p = (x.Uint64() >> 32) * uint64(n)
+		}
+	}
+	return uint32(p >> 32)
+}
+
+// Intn returns an unbiased pseudo random number in [0, n) generated by x. It panics if n <= 0.
+func Intn(x XorShift, n int) int {
+	if n <= 0 {
+		panic("xorshift: invalid argument to Intn")
+	}
+	if uint64(n) <= math.MaxUint32 {
+		return int(Uint32n(x, uint32(n)))
+	}
+	return int(Uint64n(x, uint64(n)))
+}
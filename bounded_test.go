@@ -0,0 +1,119 @@
+package xorshift
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+	"testing"
+
+	"github.com/vpxyz/xorshift/xoroshiro128plus"
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+)
+
+func TestBounded(t *testing.T) {
+	// for powers of two the result is just the upper bits of the generated value
+	for _, k := range []uint{1, 7, 32, 63} {
+		x, y := xoroshiro256starstar.NewSource(SEED), xoroshiro256starstar.NewSource(SEED)
+		for i := 0; i < 100; i++ {
+			if v, w := Uint64n(x, 1<<k), y.Uint64()>>(64-k); v != w {
+				t.Fatalf("Uint64n(2^%d) = %d, expected %d", k, v, w)
+			}
+		}
+	}
+	x, y := xoroshiro256starstar.NewSource(SEED), xoroshiro256starstar.NewSource(SEED)
+	for i := 0; i < 100; i++ {
+		if v, w := Uint32n(x, 1<<10), uint32(y.Uint64()>>54); v != w {
+			t.Fatalf("Uint32n(2^10) = %d, expected %d", v, w)
+		}
+	}
+
+	x = xoroshiro256starstar.NewSource(SEED)
+	for _, n := range []uint64{1, 3, 1000, 1<<32 + 1, 1<<63 + 1, 1<<64 - 1} {
+		for i := 0; i < 1000; i++ {
+			if v := Uint64n(x, n); v >= n {
+				t.Fatalf("Uint64n(%d) = %d", n, v)
+			}
+			if n <= 1<<32-1 {
+				if v := Uint32n(x, uint32(n)); uint64(v) >= n {
+					t.Fatalf("Uint32n(%d) = %d", n, v)
+				}
+			}
+			if n <= 1<<62 {
+				if v := Intn(x, int(n)); v < 0 || uint64(v) >= n {
+					t.Fatalf("Intn(%d) = %d", n, v)
+				}
+			}
+		}
+	}
+
+	expectPanic(t, "Uint64n(0)", func() { Uint64n(x, 0) })
+	expectPanic(t, "Uint32n(0)", func() { Uint32n(x, 0) })
+	expectPanic(t, "Intn(0)", func() { Intn(x, 0) })
+	expectPanic(t, "Intn(-1)", func() { Intn(x, -1) })
+}
+
+func TestBoundedUniform(t *testing.T) {
+	// chi-squared test with 5 degrees of freedom, the critical value for p = 0.001 is 20.515
+	const n, samples = 6, 60000
+	x := xoroshiro128plus.NewSource(SEED)
+	for _, c := range []struct {
+		name string
+		f    func() int
+	}{
+		{"Uint64n", func() int { return int(Uint64n(x, n)) }},
+		{"Uint32n", func() int { return int(Uint32n(x, n)) }},
+		{"Intn", func() int { return Intn(x, n) }},
+	} {
+		var count [n]float64
+		for i := 0; i < samples; i++ {
+			count[c.f()]++
+		}
+		chi2 := 0.0
+		for _, c := range count {
+			d := c - samples/n
+			chi2 += d * d / (samples / n)
+		}
+		if chi2 > 20.515 {
+			t.Errorf("%s(%d): chi-squared = %.2f, the values are not uniform", c.name, n, chi2)
+		}
+	}
+}
+
+func BenchmarkUint64n(b *testing.B) {
+	x := xoroshiro256starstar.NewSource(SEED)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Uint64n(x, 1000)
+	}
+}
+
+func BenchmarkUint32n(b *testing.B) {
+	x := xoroshiro256starstar.NewSource(SEED)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Uint32n(x, 1000)
+	}
+}
+
+func BenchmarkIntn(b *testing.B) {
+	x := xoroshiro256starstar.NewSource(SEED)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Intn(x, 1000)
+	}
+}
+
+func BenchmarkMathRandIntn(b *testing.B) {
+	r := rand.New(xoroshiro256starstar.NewSource(SEED))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = r.Intn(1000)
+	}
+}
+
+func BenchmarkMathRandV2IntN(b *testing.B) {
+	r := randv2.New(xoroshiro256starstar.NewSource(SEED))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = r.IntN(1000)
+	}
+}
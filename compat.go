@@ -0,0 +1,45 @@
+package xorshift
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// Every generator of the sub packages implements both rand.Source64 (Seed, Int63 and Uint64) and the
+// Source of math/rand/v2 (just Uint64), so it can be passed as is to rand.New of either package.
+// Source adapts what's missing: it wraps any Uint64 source, e.g. rand.PCG or rand.ChaCha8 of math/rand/v2,
+// and implements both interfaces.
+var (
+	_ rand.Source64 = (*Source)(nil)
+	_ randv2.Source = (*Source)(nil)
+)
+
+// Source wraps a math/rand/v2 Source, so it can be used with both math/rand and math/rand/v2.
+type Source struct {
+	src randv2.Source
+}
+
+// NewSource return a new Source that wraps src.
+func NewSource(src randv2.Source) *Source {
+	return &Source{src: src}
+}
+
+// Uint64 returns the next pseudo random number generated by the wrapped source.
+func (s *Source) Uint64() uint64 {
+	return s.src.Uint64()
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+func (s *Source) Int63() int64 {
+	return int64(s.src.Uint64() & (1<<63 - 1))
+}
+
+// Seed calls Seed(seed) of the wrapped source, as required by math/rand. The sources of math/rand/v2 have
+// different Seed functions (or none), so it panics if the wrapped source hasn't a Seed(int64) function.
+func (s *Source) Seed(seed int64) {
+	x, ok := s.src.(interface{ Seed(int64) })
+	if !ok {
+		panic("xorshift: the source has no Seed(int64) function")
+	}
+	x.Seed(seed)
+}
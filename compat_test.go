@@ -0,0 +1,89 @@
+package xorshift
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+)
+
+// isPerm reports whether p is a permutation of 0..len(p)-1.
+func isPerm(p []int) bool {
+	q := append([]int(nil), p...)
+	sort.Ints(q)
+	for i, v := range q {
+		if v != i {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRandV2(t *testing.T) {
+	for _, c := range conformanceCases {
+		x := c.load(c.state)
+		if _, ok := x.(rand.Source64); !ok {
+			t.Errorf("%s: does not implement math/rand Source64", c.name)
+		}
+		r := randv2.New(x)
+		r2 := randv2.New(c.load(c.state))
+
+		for i := 0; i < 1000; i++ {
+			if v := r.IntN(10); v < 0 || v >= 10 {
+				t.Fatalf("%s: IntN(10) = %d", c.name, v)
+			}
+			if f := r.Float64(); f < 0 || f >= 1 {
+				t.Fatalf("%s: Float64() = %v", c.name, f)
+			}
+			r2.IntN(10)
+			r2.Float64()
+		}
+
+		p := r.Perm(50)
+		if !isPerm(p) {
+			t.Errorf("%s: Perm(50) = %v, not a permutation", c.name, p)
+		}
+		s := make([]int, 50)
+		for i := range s {
+			s[i] = i
+		}
+		r.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+		if !isPerm(s) {
+			t.Errorf("%s: Shuffle didn't permute the slice: %v", c.name, s)
+		}
+
+		// the same state must give the same results
+		p2 := r2.Perm(50)
+		for i := range p {
+			if p[i] != p2[i] {
+				t.Errorf("%s: Perm(50) differs for the same state", c.name)
+				break
+			}
+		}
+	}
+}
+
+func TestSource(t *testing.T) {
+	// a math/rand/v2 source used with math/rand
+	pcg := randv2.NewPCG(1, 2)
+	r := rand.New(NewSource(randv2.NewPCG(1, 2)))
+	if v, w := r.Uint64(), pcg.Uint64(); v != w {
+		t.Errorf("Uint64() = %#x, expected %#x", v, w)
+	}
+	if v := r.Int63(); v < 0 {
+		t.Errorf("Int63() = %d, negative", v)
+	}
+	expectPanic(t, "Seed() of a wrapped PCG", func() { r.Seed(1) })
+
+	// a generator wrapped in Source works with both packages
+	s := NewSource(xoroshiro256starstar.NewSource(1))
+	s.Seed(SEED)
+	v1 := rand.New(s).Uint64()
+	s.Seed(SEED)
+	v2 := randv2.New(s).Uint64()
+	if v1 != v2 || v1 != xoroshiro256starstar.NewSource(SEED).Uint64() {
+		t.Errorf("the wrapped generator gives different values with math/rand and math/rand/v2")
+	}
+}
@@ -0,0 +1,276 @@
+package xorshift
+
+import (
+	"testing"
+
+	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/splitmix64"
+	"github.com/vpxyz/xorshift/xoroshiro128plus"
+	"github.com/vpxyz/xorshift/xoroshiro128starstar"
+	"github.com/vpxyz/xorshift/xoroshiro256plus"
+	"github.com/vpxyz/xorshift/xoroshiro256plusplus"
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+	"github.com/vpxyz/xorshift/xoroshiro512plus"
+	"github.com/vpxyz/xorshift/xoroshiro512starstar"
+	"github.com/vpxyz/xorshift/xorshift1024star"
+	"github.com/vpxyz/xorshift/xorshift1024starphi"
+	"github.com/vpxyz/xorshift/xorshift128plus"
+	"github.com/vpxyz/xorshift/xorshift4096star"
+	"github.com/vpxyz/xorshift/xorshift64star"
+)
+
+// referenceState returns n state words produced by the reference splitmix64
+// seeded with seed, the same way the reference vectors below were generated.
+func referenceState(seed int64, n int) []uint64 {
+	sm := internal.SplitMix64{}
+	sm.Seed(seed)
+	s := make([]uint64, n)
+	for i := range s {
+		s[i] = sm.Uint64()
+	}
+	return s
+}
+
+// conformanceCase holds the output of Vigna's C reference implementation for a raw state.
+// The vectors were obtained compiling the reference sources from http://prng.di.unimi.it/
+// (xorshift64*, xorshift128+, xorshift1024* and xorshift4096* from the original papers, the jump
+// polynomials of xorshift64* and xorshift4096* are the ones of this module):
+// out are the first outputs after loading the state, jump the outputs after a
+// call to jump() that follows them and longJump the outputs after a further call to long_jump().
+type conformanceCase struct {
+	name     string
+	state    []uint64
+	load     func(s []uint64) XorShift
+	out      []uint64
+	jump     []uint64
+	longJump []uint64
+}
+
+var conformanceCases = []conformanceCase{
+	{
+		name:  "splitmix64",
+		state: []uint64{0x0123456789abcdef},
+		load: func(s []uint64) XorShift {
+			x := &splitmix64.SplitMix64{}
+			x.SetState(s[0])
+			return x
+		},
+		out: []uint64{0x157a3807a48faa9d, 0xd573529b34a1d093, 0x2f90b72e996dccbe, 0xa2d419334c4667ec, 0x01404ce914938008, 0x14bc574c2a2b4c72},
+	},
+	{
+		name:  "xorshift64*",
+		state: []uint64{0x0123456789abcdef},
+		load: func(s []uint64) XorShift {
+			x := &xorshift64star.XorShift64Star{}
+			x.SetState(s[0])
+			return x
+		},
+		out:  []uint64{0x7c9482472cb6708c, 0xd5705692bf1f28de, 0x88b71e3ba5e005c0, 0x5e5d8a88f0c0cbe6, 0xbbc56f3e6b39d569, 0xed345a0aba0dd88e},
+		jump: []uint64{0xa929eda32d97ac73, 0xa44e7a6913edec44, 0x46dc416c81d576d8, 0x770bb72fe919c0a8},
+	},
+	{
+		name:  "xorshift128+",
+		state: referenceState(1, 2),
+		load: func(s []uint64) XorShift {
+			var st [2]uint64
+			copy(st[:], s)
+			x := &xorshift128plus.XorShift128Plus{}
+			x.SetState(st)
+			return x
+		},
+		out:  []uint64{0x9b3df27e919f6a0c, 0x913c0b97eddc4551, 0xb8745298b438e33c, 0xedaa33964d510ca2, 0xea5c65419ff1eca3, 0x364c9e32a6644d38},
+		jump: []uint64{0xce763ed04271b3c8, 0xdb17c8c9a6312ae0, 0x62cd5c44178ad8f9, 0xee76252bb5a52717},
+	},
+	{
+		name:  "xoroshiro128+",
+		state: referenceState(12, 2),
+		load: func(s []uint64) XorShift {
+			var st [2]uint64
+			copy(st[:], s)
+			x := &xoroshiro128plus.XoroShiro128Plus{}
+			x.SetState(st)
+			return x
+		},
+		out:      []uint64{0x84c0a4233787335a, 0x5ac1f3afc4a08fed, 0xf148ec9020b7095b, 0xe2de8a182332c678, 0x83ad5fe42ddaf124, 0x9d72d0b49471e8de},
+		jump:     []uint64{0x4ad9ebf2f119a5af, 0xa29925891e6e3274, 0xcc65c8cece83ef6c, 0x072b633e748a1a3a},
+		longJump: []uint64{0x357c786e8e05d2d9, 0xba2146cc82f00de0, 0xb264a114bc51f681, 0x84d63e90b1d06d27},
+	},
+	{
+		name:  "xoroshiro128+ (2016)",
+		state: referenceState(2, 2),
+		load: func(s []uint64) XorShift {
+			var st [2]uint64
+			copy(st[:], s)
+			x := &xoroshiro128plus.XoroShiro128PlusLegacy{}
+			x.SetState(st)
+			return x
+		},
+		out:      []uint64{0x57207bee28937510, 0xc9dce2d0b34d4008, 0xb69a6a944572c991, 0xd84b3ed9eff747fe, 0x364b2654462e1b47, 0x640088df00ba7bcd},
+		jump:     []uint64{0x349c5bd7fd0615d9, 0x952025eae80361e9, 0x7a70de4b527fdc75, 0xe9eb60ec07defef6},
+		longJump: []uint64{0x09f2bae27ed87894, 0xecd8d4606489c2bc, 0xac833527abd8959f, 0x6e39e2adca57e510},
+	},
+	{
+		name:  "xoroshiro128**",
+		state: referenceState(3, 2),
+		load: func(s []uint64) XorShift {
+			var st [2]uint64
+			copy(st[:], s)
+			x := &xoroshiro128starstar.XoroShiro128StarStar{}
+			x.SetState(st)
+			return x
+		},
+		out:      []uint64{0x79561d3fa3265708, 0x5f318ffd667e5c5c, 0xba511e6633b7160f, 0x69ead4febcff31c1, 0x4dd88b5ffd032239, 0x3ca74da4ebfea149},
+		jump:     []uint64{0x75ff64df82b465dd, 0x726f8eab00f7c024, 0xb75caa3f897ac4a9, 0xed2800879dfe9d24},
+		longJump: []uint64{0x1e3f8be11f04ba3d, 0xba20e65b080c1da0, 0x16c2220670d4be1c, 0xf542b12593ff7f1f},
+	},
+	{
+		name:  "xoroshiro256+",
+		state: referenceState(4, 4),
+		load: func(s []uint64) XorShift {
+			var st [4]uint64
+			copy(st[:], s)
+			x := &xoroshiro256plus.XoroShiro256Plus{}
+			x.SetState(st)
+			return x
+		},
+		out:      []uint64{0xec58ce7f09278368, 0x60f9a14695059011, 0x5d7f28e26b1f75bb, 0x35001bb707880aa2, 0xf15ddf70b6de802b, 0xd1f47062c9dc546c},
+		jump:     []uint64{0x3f4aeddfa4ef2689, 0xb4465bb4e8a85c7a, 0x7d1feffd1f23286c, 0x3612a801aced1136},
+		longJump: []uint64{0xb2d12e74bf8193eb, 0x6c6d3da9780097f1, 0xb5d86c1030ff8bd3, 0x629a5b8820fa9f93},
+	},
+	{
+		name:  "xoroshiro256++",
+		state: referenceState(5, 4),
+		load: func(s []uint64) XorShift {
+			var st [4]uint64
+			copy(st[:], s)
+			x := &xoroshiro256plusplus.XoroShiro256PlusPlus{}
+			x.SetState(st)
+			return x
+		},
+		out:      []uint64{0x4ac202caf347fc1e, 0x9c874b1ef6a1c5e6, 0x19141eb775a6f43f, 0x0f0124ccd0060d9e, 0x86ed2b84822f1fff, 0xb4054adcc419bdaa},
+		jump:     []uint64{0xe4ff7d7ed0c24c84, 0x2f6ea24ffa29f67e, 0x250113f53c78be38, 0xf8100934e4129a80},
+		longJump: []uint64{0x2900da749c810bf3, 0xd84ebf7ab22f24f0, 0x0e771282e768cceb, 0x35672f867a009ea3},
+	},
+	{
+		name:  "xoroshiro256**",
+		state: referenceState(6, 4),
+		load: func(s []uint64) XorShift {
+			var st [4]uint64
+			copy(st[:], s)
+			x := &xoroshiro256starstar.XoroShiro256StarStar{}
+			x.SetState(st)
+			return x
+		},
+		out:      []uint64{0xc45c2a09b226f385, 0xf099b838c37b9cfb, 0xe0faba6aa9a5a848, 0x24413854e076f493, 0x3043a951cb5a5671, 0x0b9fe9a6f4395069},
+		jump:     []uint64{0xb6523d068a6ee634, 0x3c22db8735fcd683, 0xcc63589356999842, 0x159be8e3a9b1be2e},
+		longJump: []uint64{0x8e301d48a5e5c1bd, 0x244bcf9d2a1afb64, 0xc9c1b27e650ba687, 0x874b88a66b365cdc},
+	},
+	{
+		name:  "xoroshiro512+",
+		state: referenceState(7, 8),
+		load: func(s []uint64) XorShift {
+			var st [8]uint64
+			copy(st[:], s)
+			x := &xoroshiro512plus.XoroShiro512Plus{}
+			x.SetState(st)
+			return x
+		},
+		out:      []uint64{0x4a64226513e337d9, 0x9953c6aa4e7404f6, 0x563ee78669b275d6, 0xffe811c1e4b3c60d, 0xf9aebae2d9bef557, 0x1c523534bae24898},
+		jump:     []uint64{0xc81a11ff826e1616, 0xaf354ad19bfe32f9, 0xe39433f5447898a7, 0xe09adeb47d60dd86},
+		longJump: []uint64{0xbf2c6e86c60250a8, 0xd5933b81b0eea110, 0x29f239a9ddc8ad0e, 0x0c48b71c3aee8148},
+	},
+	{
+		name:  "xoroshiro512**",
+		state: referenceState(8, 8),
+		load: func(s []uint64) XorShift {
+			var st [8]uint64
+			copy(st[:], s)
+			x := &xoroshiro512starstar.XoroShiro512StarStar{}
+			x.SetState(st)
+			return x
+		},
+		out:      []uint64{0xd22f5048870c16bf, 0x9ace999dbf3cc12e, 0x97292618b9de79a8, 0x1c54b879d36f8dfd, 0x31b078c7710375e5, 0xcdc2928ce9a0ddf8},
+		jump:     []uint64{0x3610a1228174983e, 0x126884b10138f794, 0x4a9c2c28be3de9bb, 0x5abff6bea4d57461},
+		longJump: []uint64{0xe342c55d74a5437a, 0xd30893b2b1d016ac, 0x30e03ec96ac70d6c, 0x5f642456cf21e62f},
+	},
+	{
+		name:  "xorshift1024*",
+		state: referenceState(9, 16),
+		load: func(s []uint64) XorShift {
+			var st [16]uint64
+			copy(st[:], s)
+			x := &xorshift1024star.XorShift1024Star{}
+			x.SetState(st)
+			return x
+		},
+		out:  []uint64{0x50823a41ab5ac1b5, 0xa47f4351ec8ccf23, 0x9c637c6e1cd20bfe, 0x4793eb0040394aea, 0x9b58b1203d694de2, 0x559240ba58d5f895},
+		jump: []uint64{0x0c43b7b6a440c3f8, 0xfd0fa48405168a3c, 0xf2489a5ed4294f11, 0x7ae009088ccee82c},
+	},
+	{
+		name:  "xorshift1024*phi",
+		state: referenceState(10, 16),
+		load: func(s []uint64) XorShift {
+			var st [16]uint64
+			copy(st[:], s)
+			x := &xorshift1024starphi.XorShift1024StarPhi{}
+			x.SetState(st)
+			return x
+		},
+		out:  []uint64{0x0d0b93d9a2340dfd, 0xa2e4b823e707775f, 0x63521747a4346762, 0xc51479d104600a66, 0x638f9cc6fff24f8c, 0xa26c9f9a0c59edb8},
+		jump: []uint64{0xbe0ba2085d3decca, 0x22e3821a4e6168e3, 0x9a79f8a0cd8d9c22, 0x15f4a28fed9dcbc0},
+	},
+	{
+		name:  "xorshift4096*",
+		state: referenceState(11, 64),
+		load: func(s []uint64) XorShift {
+			var st [64]uint64
+			copy(st[:], s)
+			x := &xorshift4096star.XorShift4096Star{}
+			x.SetState(st)
+			return x
+		},
+		out:  []uint64{0x94d94bccb8874dfa, 0xc661ddc9662bc0e2, 0x3b3d351123e5c725, 0xab5fd68b873fe006, 0xf843aba05895cb88, 0xa3b49820a527f221},
+		jump: []uint64{0x2dd38a23415e2860, 0x9eb29ba6036b6a3c, 0xf4e87e91a84ef92e, 0x90a2c78e1ea7ad50},
+	},
+}
+
+func TestConformance(t *testing.T) {
+	for _, c := range conformanceCases {
+		x := c.load(c.state)
+		for i, want := range c.out {
+			if got := x.Uint64(); got != want {
+				t.Errorf("%s: output %d = %#016x, want %#016x", c.name, i, got, want)
+			}
+		}
+
+		if c.jump == nil {
+			continue
+		}
+		xj, ok := x.(XorShiftExt)
+		if !ok {
+			t.Errorf("%s: does not implement XorShiftExt", c.name)
+			continue
+		}
+		xj.Jump()
+		for i, want := range c.jump {
+			if got := xj.Uint64(); got != want {
+				t.Errorf("%s: output %d after Jump = %#016x, want %#016x", c.name, i, got, want)
+			}
+		}
+
+		if c.longJump == nil {
+			continue
+		}
+		xl, ok := x.(XorShiftLongJumper)
+		if !ok {
+			t.Errorf("%s: does not implement XorShiftLongJumper", c.name)
+			continue
+		}
+		xl.LongJump()
+		for i, want := range c.longJump {
+			if got := xj.Uint64(); got != want {
+				t.Errorf("%s: output %d after LongJump = %#016x, want %#016x", c.name, i, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package xorshift
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/vpxyz/xorshift/distributions"
+	"github.com/vpxyz/xorshift/xoroshiro256plusplus"
+)
+
+// ksTest tests the n values generated by f against the cumulative distribution function cdf
+// with the Kolmogorov-Smirnov test, at the significance level 0.001.
+func ksTest(t *testing.T, name string, n int, f func() float64, cdf func(x float64) float64) {
+	t.Helper()
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = f()
+		if math.IsNaN(v[i]) {
+			t.Fatalf("%s: NaN generated", name)
+		}
+	}
+	sort.Float64s(v)
+
+	var d float64
+	for i, x := range v {
+		c := cdf(x)
+		d = math.Max(d, math.Max(float64(i+1)/float64(n)-c, c-float64(i)/float64(n)))
+	}
+	if crit := 1.9495 / math.Sqrt(float64(n)); d > crit {
+		t.Errorf("%s: Kolmogorov-Smirnov D = %.5f, critical value %.5f", name, d, crit)
+	}
+}
+
+// regGammaP returns the regularized lower incomplete gamma function P(a, x).
+func regGammaP(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	lg, _ := math.Lgamma(a)
+	if x < a+1 {
+		// series expansion
+		sum, term := 1/a, 1/a
+		for n := 1.0; n < 1000; n++ {
+			term *= x / (a + n)
+			sum += term
+			if term < sum*1e-15 {
+				break
+			}
+		}
+		return sum * math.Exp(-x+a*math.Log(x)-lg)
+	}
+	// continued fraction for Q(a, x), with the modified Lentz's method
+	return 1 - math.Exp(-x+a*math.Log(x)-lg)*lentz(func(n int) (float64, float64) {
+		if n == 0 {
+			return 0, x + 1 - a
+		}
+		return -float64(n) * (float64(n) - a), x + 1 - a + 2*float64(n)
+	})
+}
+
+// regBetaI returns the regularized incomplete beta function I_x(a, b).
+func regBetaI(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	if x > (a+1)/(a+b+2) {
+		return 1 - regBetaI(b, a, 1-x)
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	return front / a * lentz(func(n int) (float64, float64) {
+		if n == 0 {
+			return 0, 1
+		}
+		m := float64(n / 2)
+		if n%2 == 0 {
+			return m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m)), 1
+		}
+		return -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1)), 1
+	})
+}
+
+// lentz evaluates the continued fraction b0 + a1/(b1 + a2/(b2 + ...)), term returns a_n, b_n.
+func lentz(term func(n int) (a, b float64)) float64 {
+	const tiny = 1e-300
+	_, b0 := term(0)
+	f := b0
+	if f == 0 {
+		f = tiny
+	}
+	c, d := f, 0.0
+	for n := 1; n < 10000; n++ {
+		a, b := term(n)
+		d = b + a*d
+		if d == 0 {
+			d = tiny
+		}
+		c = b + a/c
+		if c == 0 {
+			c = tiny
+		}
+		d = 1 / d
+		delta := c * d
+		f *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return 1 / f
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func TestContinuous(t *testing.T) {
+	const n = 100000
+	x := xoroshiro256plusplus.NewSource(SEED)
+
+	for _, c := range []struct {
+		name string
+		f    func() float64
+		cdf  func(v float64) float64
+	}{
+		{"NormFloat64", func() float64 { return distributions.NormFloat64(x) }, normCDF},
+		{"ExpFloat64", func() float64 { return distributions.ExpFloat64(x) }, func(v float64) float64 { return -math.Expm1(-v) }},
+		{"Gamma(0.3, 2)", func() float64 { return distributions.Gamma(x, 0.3, 2) }, func(v float64) float64 { return regGammaP(0.3, v/2) }},
+		{"Gamma(1, 1)", func() float64 { return distributions.Gamma(x, 1, 1) }, func(v float64) float64 { return regGammaP(1, v) }},
+		{"Gamma(7.5, 0.5)", func() float64 { return distributions.Gamma(x, 7.5, 0.5) }, func(v float64) float64 { return regGammaP(7.5, v/0.5) }},
+		{"Beta(0.5, 0.7)", func() float64 { return distributions.Beta(x, 0.5, 0.7) }, func(v float64) float64 { return regBetaI(0.5, 0.7, v) }},
+		{"Beta(2, 5)", func() float64 { return distributions.Beta(x, 2, 5) }, func(v float64) float64 { return regBetaI(2, 5, v) }},
+		{"Beta(0.5, 3)", func() float64 { return distributions.Beta(x, 0.5, 3) }, func(v float64) float64 { return regBetaI(0.5, 3, v) }},
+		{"ChiSquared(3)", func() float64 { return distributions.ChiSquared(x, 3) }, func(v float64) float64 { return regGammaP(1.5, v/2) }},
+		{"StudentT(1.5)", func() float64 { return distributions.StudentT(x, 1.5) }, studentCDF(1.5)},
+		{"StudentT(10)", func() float64 { return distributions.StudentT(x, 10) }, studentCDF(10)},
+		{"Cauchy(1, 2)", func() float64 { return distributions.Cauchy(x, 1, 2) }, func(v float64) float64 { return 0.5 + math.Atan((v-1)/2)/math.Pi }},
+		{"LogNormal(0.5, 0.8)", func() float64 { return distributions.LogNormal(x, 0.5, 0.8) }, func(v float64) float64 { return normCDF((math.Log(v) - 0.5) / 0.8) }},
+		{"Weibull(1.5, 3)", func() float64 { return distributions.Weibull(x, 1.5, 3) }, func(v float64) float64 { return -math.Expm1(-math.Pow(v/3, 1.5)) }},
+		{"Pareto(2, 3)", func() float64 { return distributions.Pareto(x, 2, 3) }, func(v float64) float64 { return 1 - math.Pow(2/v, 3) }},
+	} {
+		ksTest(t, c.name, n, c.f, c.cdf)
+	}
+}
+
+func studentCDF(df float64) func(v float64) float64 {
+	return func(v float64) float64 {
+		p := 0.5 * regBetaI(df/2, 0.5, df/(df+v*v))
+		if v > 0 {
+			return 1 - p
+		}
+		return p
+	}
+}
+
+func TestContinuousDeterministic(t *testing.T) {
+	x, y := xoroshiro256plusplus.NewSource(SEED), xoroshiro256plusplus.NewSource(SEED)
+	for i := 0; i < 1000; i++ {
+		if distributions.Gamma(x, 2.5, 1) != distributions.Gamma(y, 2.5, 1) ||
+			distributions.Beta(x, 0.5, 0.5) != distributions.Beta(y, 0.5, 0.5) ||
+			distributions.StudentT(x, 3) != distributions.StudentT(y, 3) {
+			t.Fatal("the same generator state gives different values")
+		}
+	}
+}
+
+func TestContinuousInvalid(t *testing.T) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	expectPanic(t, "Gamma(0, 1)", func() { distributions.Gamma(x, 0, 1) })
+	expectPanic(t, "Beta(1, -1)", func() { distributions.Beta(x, 1, -1) })
+	expectPanic(t, "ChiSquared(0)", func() { distributions.ChiSquared(x, 0) })
+	expectPanic(t, "StudentT(NaN)", func() { distributions.StudentT(x, math.NaN()) })
+	expectPanic(t, "Cauchy(0, 0)", func() { distributions.Cauchy(x, 0, 0) })
+	expectPanic(t, "LogNormal(0, -1)", func() { distributions.LogNormal(x, 0, -1) })
+	expectPanic(t, "Weibull(0, 1)", func() { distributions.Weibull(x, 0, 1) })
+	expectPanic(t, "Pareto(1, 0)", func() { distributions.Pareto(x, 1, 0) })
+}
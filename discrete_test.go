@@ -0,0 +1,189 @@
+package xorshift
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vpxyz/xorshift/distributions"
+	"github.com/vpxyz/xorshift/xoroshiro256plusplus"
+)
+
+// chiSquaredCritical returns the critical value of the chi-squared distribution with df degrees of freedom
+// for a significance level of 0.001, with the Wilson-Hilferty approximation.
+func chiSquaredCritical(df int) float64 {
+	const z = 3.0902 // the 0.999 quantile of the standard normal distribution
+	k := float64(df)
+	h := 2 / (9 * k)
+	return k * math.Pow(1-h+z*math.Sqrt(h), 3)
+}
+
+// chiSquared tests the n values generated by f against the unimodal probability mass function pmf.
+// The tested range starts from the mode, and extends in both directions while the mass is not negligible.
+func chiSquared(t *testing.T, name string, n int, f func() int, pmf func(k int) float64) {
+	t.Helper()
+	counts := map[int]int{}
+	for i := 0; i < n; i++ {
+		counts[f()]++
+	}
+
+	mode, best := 0, 0.0
+	for k := range counts {
+		if p := pmf(k); p > best {
+			mode, best = k, p
+		}
+	}
+	lo, hi := mode, mode
+	for lo > 0 && pmf(lo-1)*float64(n) > 1e-3 {
+		lo--
+	}
+	for pmf(hi+1)*float64(n) > 1e-3 {
+		hi++
+	}
+	chiSquaredCounts(t, name, n, counts, pmf, lo, hi)
+}
+
+// chiSquaredCounts tests the counts of n values against the probability mass function pmf, in the range [lo, hi].
+// The consecutive values are grouped in bins with an expected count of at least 5.
+func chiSquaredCounts(t *testing.T, name string, n int, counts map[int]int, pmf func(k int) float64, lo, hi int) {
+	t.Helper()
+	for k := range counts {
+		if k < lo || k > hi {
+			t.Errorf("%s: %d values equal to %d, the expected count is %.2g", name, counts[k], k, pmf(k)*float64(n))
+		}
+	}
+
+	var exp, obs []float64
+	var e, o float64
+	for k := lo; k <= hi; k++ {
+		e += pmf(k) * float64(n)
+		o += float64(counts[k])
+		if e >= 5 {
+			exp, obs = append(exp, e), append(obs, o)
+			e, o = 0, 0
+		}
+	}
+	if len(exp) > 0 { // the residual mass goes to the last bin
+		exp[len(exp)-1] += e
+		obs[len(obs)-1] += o
+	}
+
+	var chi2 float64
+	for i := range exp {
+		d := obs[i] - exp[i]
+		chi2 += d * d / exp[i]
+	}
+	bins := len(exp)
+	if crit := chiSquaredCritical(bins - 1); chi2 > crit {
+		t.Errorf("%s: chi-squared = %.1f with %d degrees of freedom, critical value %.1f", name, chi2, bins-1, crit)
+	}
+}
+
+func poissonPMF(mu float64) func(k int) float64 {
+	return func(k int) float64 {
+		if k < 0 {
+			return 0
+		}
+		lg, _ := math.Lgamma(float64(k) + 1)
+		return math.Exp(float64(k)*math.Log(mu) - mu - lg)
+	}
+}
+
+func binomialPMF(n int, p float64) func(k int) float64 {
+	return func(k int) float64 {
+		if k < 0 || k > n {
+			return 0
+		}
+		a, _ := math.Lgamma(float64(n) + 1)
+		b, _ := math.Lgamma(float64(k) + 1)
+		c, _ := math.Lgamma(float64(n-k) + 1)
+		return math.Exp(a - b - c + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+	}
+}
+
+func negativeBinomialPMF(r, p float64) func(k int) float64 {
+	return func(k int) float64 {
+		if k < 0 {
+			return 0
+		}
+		a, _ := math.Lgamma(float64(k) + r)
+		b, _ := math.Lgamma(float64(k) + 1)
+		c, _ := math.Lgamma(r)
+		return math.Exp(a - b - c + r*math.Log(p) + float64(k)*math.Log1p(-p))
+	}
+}
+
+func TestPoisson(t *testing.T) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	for _, mu := range []float64{0.3, 4.5, 10, 47.3, 1000} {
+		chiSquared(t, "Poisson", 200000, func() int { return distributions.Poisson(x, mu) }, poissonPMF(mu))
+	}
+	if v := distributions.Poisson(x, 0); v != 0 {
+		t.Errorf("Poisson(0) = %d", v)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	for _, c := range []struct {
+		n int
+		p float64
+	}{{20, 0.3}, {100, 0.9}, {1000, 0.4}, {500, 0.95}, {100000, 0.5}, {77, 0.5}} {
+		chiSquared(t, "Binomial", 200000, func() int { return distributions.Binomial(x, c.n, c.p) }, binomialPMF(c.n, c.p))
+	}
+	if v := distributions.Binomial(x, 10, 1); v != 10 {
+		t.Errorf("Binomial(10, 1) = %d", v)
+	}
+	if v := distributions.Binomial(x, 10, 0); v != 0 {
+		t.Errorf("Binomial(10, 0) = %d", v)
+	}
+}
+
+func TestGeometric(t *testing.T) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	for _, p := range []float64{0.05, 0.5, 0.99} {
+		chiSquared(t, "Geometric", 200000, func() int { return distributions.Geometric(x, p) }, negativeBinomialPMF(1, p))
+	}
+}
+
+func TestNegativeBinomial(t *testing.T) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	for _, c := range []struct{ r, p float64 }{{3.5, 0.3}, {50, 0.9}, {0.5, 0.2}} {
+		chiSquared(t, "NegativeBinomial", 200000, func() int { return distributions.NegativeBinomial(x, c.r, c.p) }, negativeBinomialPMF(c.r, c.p))
+	}
+}
+
+func TestDiscreteDeterministic(t *testing.T) {
+	x, y := xoroshiro256plusplus.NewSource(SEED), xoroshiro256plusplus.NewSource(SEED)
+	for i := 0; i < 1000; i++ {
+		if distributions.Poisson(x, 100) != distributions.Poisson(y, 100) ||
+			distributions.Binomial(x, 1000, 0.3) != distributions.Binomial(y, 1000, 0.3) ||
+			distributions.NegativeBinomial(x, 2.5, 0.4) != distributions.NegativeBinomial(y, 2.5, 0.4) {
+			t.Fatal("the same generator state gives different values")
+		}
+	}
+}
+
+func TestDiscreteInvalid(t *testing.T) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	expectPanic(t, "Poisson(-1)", func() { distributions.Poisson(x, -1) })
+	expectPanic(t, "Poisson(NaN)", func() { distributions.Poisson(x, math.NaN()) })
+	expectPanic(t, "Poisson(+Inf)", func() { distributions.Poisson(x, math.Inf(1)) })
+	expectPanic(t, "Poisson(1e19)", func() { distributions.Poisson(x, 1e19) })
+	expectPanic(t, "Binomial(-1, 0.5)", func() { distributions.Binomial(x, -1, 0.5) })
+	expectPanic(t, "Binomial(10, 1.5)", func() { distributions.Binomial(x, 10, 1.5) })
+	expectPanic(t, "Geometric(0)", func() { distributions.Geometric(x, 0) })
+	expectPanic(t, "NegativeBinomial(0, 0.5)", func() { distributions.NegativeBinomial(x, 0, 0.5) })
+
+	// extreme but valid arguments never overflow int
+	for i := 0; i < 1000; i++ {
+		if v := distributions.Geometric(x, 1e-18); v < 0 {
+			t.Fatalf("Geometric(1e-18) = %d", v)
+		}
+		if v := distributions.NegativeBinomial(x, 1, 1e-19); v < 0 {
+			t.Fatalf("NegativeBinomial(1, 1e-19) = %d", v)
+		}
+		if v := distributions.Poisson(x, 1e8); v < 0 || math.Abs(float64(v)-1e8) > 1e6 {
+			t.Fatalf("Poisson(1e8) = %d", v)
+		}
+	}
+}
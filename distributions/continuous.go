@@ -0,0 +1,122 @@
+package distributions
+
+import "math"
+
+// Gamma returns a gamma distributed float64 with the given shape and scale (mean shape*scale),
+// with the method of Marsaglia and Tsang ("A simple method for generating gamma variables", 2000).
+// It panics if shape <= 0 or scale <= 0.
+func Gamma(src Source, shape, scale float64) float64 {
+	if !(shape > 0) || !(scale > 0) {
+		panic("distributions: invalid argument to Gamma")
+	}
+	return gamma(src, shape) * scale
+}
+
+// gamma returns a gamma distributed float64 with shape a and scale 1. For a < 1 it uses Gamma(a+1) * U^(1/a).
+func gamma(src Source, a float64) float64 {
+	if a < 1 {
+		return gamma(src, a+1) * math.Pow(uniformOpen(src), 1/a)
+	}
+	d := a - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := NormFloat64(src)
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := uniformOpen(src)
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// Beta returns a beta distributed float64 in [0, 1], with shape parameters a and b.
+// If both a and b are at most 1 it uses Jöhnk's algorithm, otherwise the ratio X/(X+Y) of two
+// gamma variates. It panics if a <= 0 or b <= 0.
+func Beta(src Source, a, b float64) float64 {
+	if !(a > 0) || !(b > 0) {
+		panic("distributions: invalid argument to Beta")
+	}
+	if a > 1 || b > 1 {
+		x := gamma(src, a)
+		return x / (x + gamma(src, b))
+	}
+
+	for {
+		u := uniform(src)
+		v := uniform(src)
+		x := math.Pow(u, 1/a)
+		y := math.Pow(v, 1/b)
+		if x+y > 1 || u+v == 0 {
+			continue
+		}
+		if x+y > 0 {
+			return x / (x + y)
+		}
+		// x and y underflow, use their logarithms
+		lx := math.Log(u) / a
+		ly := math.Log(v) / b
+		lm := math.Max(lx, ly)
+		lx -= lm
+		ly -= lm
+		return math.Exp(lx - math.Log(math.Exp(lx)+math.Exp(ly)))
+	}
+}
+
+// ChiSquared returns a chi-squared distributed float64 with df degrees of freedom, that is a
+// gamma variate with shape df/2 and scale 2. It panics if df <= 0.
+func ChiSquared(src Source, df float64) float64 {
+	if !(df > 0) {
+		panic("distributions: invalid argument to ChiSquared")
+	}
+	return 2 * gamma(src, df/2)
+}
+
+// StudentT returns a Student's t distributed float64 with df degrees of freedom,
+// as Z / sqrt(V/df) with Z normal and V chi-squared. It panics if df <= 0.
+func StudentT(src Source, df float64) float64 {
+	if !(df > 0) {
+		panic("distributions: invalid argument to StudentT")
+	}
+	z := NormFloat64(src)
+	return z / math.Sqrt(2*gamma(src, df/2)/df)
+}
+
+// Cauchy returns a Cauchy distributed float64 with the given location (the median) and scale,
+// by inversion. It panics if scale <= 0.
+func Cauchy(src Source, location, scale float64) float64 {
+	if !(scale > 0) {
+		panic("distributions: invalid argument to Cauchy")
+	}
+	return location + scale*math.Tan(math.Pi*(uniformOpen(src)-0.5))
+}
+
+// LogNormal returns a log-normally distributed float64, exp(mu + sigma*Z) with Z normal.
+// It panics if sigma < 0.
+func LogNormal(src Source, mu, sigma float64) float64 {
+	if !(sigma >= 0) {
+		panic("distributions: invalid argument to LogNormal")
+	}
+	return math.Exp(mu + sigma*NormFloat64(src))
+}
+
+// Weibull returns a Weibull distributed float64 with the given shape k and scale lambda,
+// as lambda * E^(1/k) with E exponential. It panics if shape <= 0 or scale <= 0.
+func Weibull(src Source, shape, scale float64) float64 {
+	if !(shape > 0) || !(scale > 0) {
+		panic("distributions: invalid argument to Weibull")
+	}
+	return scale * math.Pow(ExpFloat64(src), 1/shape)
+}
+
+// Pareto returns a Pareto distributed float64 in [scale, +Inf), with the given scale (the minimum value xm)
+// and shape alpha, as scale * exp(E/alpha) with E exponential. It panics if scale <= 0 or shape <= 0.
+func Pareto(src Source, scale, shape float64) float64 {
+	if !(scale > 0) || !(shape > 0) {
+		panic("distributions: invalid argument to Pareto")
+	}
+	return scale * math.Exp(ExpFloat64(src)/shape)
+}
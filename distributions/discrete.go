@@ -0,0 +1,252 @@
+package distributions
+
+import "math"
+
+// The discrete distributions are exact: they don't use the normal approximation, for any parameter.
+
+// maxPoissonMean is the largest mean accepted by Poisson: it leaves room for the spread of the
+// values, so they always fit in an int.
+const maxPoissonMean = math.MaxInt / 2
+
+// toInt converts the integral value k >= 0 to int, saturating at math.MaxInt.
+func toInt(k float64) int {
+	if k >= math.MaxInt {
+		return math.MaxInt
+	}
+	return int(k)
+}
+
+// Poisson returns a Poisson distributed int with mean mu. For mu < 10 it uses the multiplication method,
+// otherwise Hörmann's transformed rejection with squeeze, PTRS ("The transformed rejection method for
+// generating Poisson random variables", 1993), that needs about 2 uniform values for any mu.
+// It panics if mu < 0, if mu isn't finite or if it's larger than math.MaxInt/2.
+func Poisson(src Source, mu float64) int {
+	switch {
+	case !(mu >= 0 && mu <= maxPoissonMean):
+		panic("distributions: invalid argument to Poisson")
+	case mu == 0:
+		return 0
+	case mu < 10:
+		return poissonMult(src, mu)
+	}
+	return poissonPTRS(src, mu)
+}
+
+// poissonMult multiplies uniform values until the product falls below exp(-mu).
+func poissonMult(src Source, mu float64) int {
+	limit := math.Exp(-mu)
+	k := 0
+	prod := uniform(src)
+	for prod > limit {
+		k++
+		prod *= uniform(src)
+	}
+	return k
+}
+
+// poissonPTRS is the PTRS algorithm, for mu >= 10.
+func poissonPTRS(src Source, mu float64) int {
+	smu := math.Sqrt(mu)
+	logMu := math.Log(mu)
+	b := 0.931 + 2.53*smu
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+
+	for {
+		u := uniform(src) - 0.5
+		v := uniform(src)
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + mu + 0.43)
+		if us >= 0.07 && v <= vr {
+			return toInt(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -mu+k*logMu-lg {
+			return toInt(k)
+		}
+	}
+}
+
+// Binomial returns a binomially distributed int, the number of successes in n trials
+// with probability of success p. If n*min(p, 1-p) <= 30 it uses the inversion of the distribution,
+// otherwise the BTPE algorithm of Kachitvichyanukul and Schmeiser ("Binomial random variate generation", 1988).
+// It panics if n < 0 or p isn't in [0, 1].
+func Binomial(src Source, n int, p float64) int {
+	if n < 0 || !(p >= 0 && p <= 1) {
+		panic("distributions: invalid argument to Binomial")
+	}
+	if n == 0 || p == 0 {
+		return 0
+	}
+	if p > 0.5 {
+		return n - Binomial(src, n, 1-p)
+	}
+	if float64(n)*p <= 30 {
+		return binomialInversion(src, n, p)
+	}
+	return binomialBTPE(src, n, p)
+}
+
+// binomialInversion inverts the distribution function, walking from 0, for p <= 0.5 and small n*p.
+func binomialInversion(src Source, n int, p float64) int {
+	q := 1 - p
+	qn := math.Exp(float64(n) * math.Log1p(-p))
+	np := float64(n) * p
+	bound := math.Min(float64(n), np+10*math.Sqrt(np*q+1))
+
+	k := 0
+	px := qn
+	u := uniform(src)
+	for u > px {
+		k++
+		if float64(k) > bound {
+			// rounding errors made u unreachable, restart
+			k = 0
+			px = qn
+			u = uniform(src)
+		} else {
+			u -= px
+			px = float64(n-k+1) * p * px / (float64(k) * q)
+		}
+	}
+	return k
+}
+
+// binomialBTPE is the BTPE algorithm (triangle, parallelogram, exponential tails), for p <= 0.5 and n*p > 30.
+func binomialBTPE(src Source, n int, p float64) int {
+	nf := float64(n)
+	r := p
+	q := 1 - p
+	nrq := nf * r * q
+	fm := nf*r + r
+	m := math.Floor(fm)
+	p1 := math.Floor(2.195*math.Sqrt(nrq)-4.6*q) + 0.5
+	xm := m + 0.5
+	xl := xm - p1
+	xr := xm + p1
+	c := 0.134 + 20.5/(15.3+m)
+	a := (fm - xl) / (fm - xl*r)
+	laml := a * (1 + a/2)
+	a = (xr - fm) / (xr * q)
+	lamr := a * (1 + a/2)
+	p2 := p1 * (1 + 2*c)
+	p3 := p2 + c/laml
+	p4 := p3 + c/lamr
+
+	for {
+		u := uniform(src) * p4
+		v := uniform(src)
+		var y float64
+		switch {
+		case u <= p1:
+			// triangular region, accepted at once
+			return int(math.Floor(xm - p1*v + u))
+		case u <= p2:
+			// parallelogram region
+			x := xl + (u-p1)/c
+			v = v*c + 1 - math.Abs(m-x+0.5)/p1
+			if v > 1 {
+				continue
+			}
+			y = math.Floor(x)
+		case u <= p3:
+			// left exponential tail
+			y = math.Floor(xl + math.Log(v)/laml)
+			if y < 0 || v == 0 {
+				continue
+			}
+			v *= (u - p2) * laml
+		default:
+			// right exponential tail
+			y = math.Floor(xr - math.Log(v)/lamr)
+			if y > nf || v == 0 {
+				continue
+			}
+			v *= (u - p3) * lamr
+		}
+
+		k := math.Abs(y - m)
+		if k <= 20 || k >= nrq/2-1 {
+			// explicit evaluation of f(y)/f(m)
+			s := r / q
+			a := s * (nf + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/i - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/i - s
+				}
+			}
+			if v <= f {
+				return int(y)
+			}
+			continue
+		}
+
+		// squeeze using the upper and lower bounds of log(f(y)/f(m))
+		rho := (k / nrq) * ((k*(k/3+0.625)+0.16666666666666666)/nrq + 0.5)
+		t := -k * k / (2 * nrq)
+		lv := math.Log(v)
+		if lv < t-rho {
+			return int(y)
+		}
+		if lv > t+rho {
+			continue
+		}
+
+		// final acceptance test, with Stirling's formula
+		x1 := y + 1
+		f1 := m + 1
+		z := nf + 1 - m
+		w := nf - y + 1
+		if lv <= xm*math.Log(f1/x1)+(nf-m+0.5)*math.Log(z/w)+(y-m)*math.Log(w*r/(x1*q))+
+			stirling(f1)+stirling(z)+stirling(x1)+stirling(w) {
+			return int(y)
+		}
+	}
+}
+
+// stirling is the correction term of Stirling's formula, used by BTPE.
+func stirling(x float64) float64 {
+	x2 := x * x
+	return (13680 - (462-(132-(99-140/x2)/x2)/x2)/x2) / x / 166320
+}
+
+// Geometric returns a geometrically distributed int, the number of failures before the first success
+// in independent trials with probability of success p, by inversion. It panics if p isn't in (0, 1].
+// For a tiny p the values can be larger than math.MaxInt: they are saturated to math.MaxInt.
+func Geometric(src Source, p float64) int {
+	if !(p > 0 && p <= 1) {
+		panic("distributions: invalid argument to Geometric")
+	}
+	if p == 1 {
+		return 0
+	}
+	return toInt(math.Floor(math.Log(uniformOpen(src)) / math.Log1p(-p)))
+}
+
+// NegativeBinomial returns a negative binomially distributed int, the number of failures before r successes
+// in independent trials with probability of success p. r can be any positive real number: the value is
+// generated as a Poisson variate whose mean has a gamma distribution of shape r and scale (1-p)/p.
+// It panics if r <= 0 or p isn't in (0, 1]. For a tiny p the mean drawn can be larger than the one
+// accepted by Poisson: in that case the value is saturated to math.MaxInt.
+func NegativeBinomial(src Source, r, p float64) int {
+	if !(r > 0) || !(p > 0 && p <= 1) {
+		panic("distributions: invalid argument to NegativeBinomial")
+	}
+	if p == 1 {
+		return 0
+	}
+	mu := gamma(src, r) * (1 - p) / p
+	if mu > maxPoissonMean {
+		return math.MaxInt
+	}
+	return Poisson(src, mu)
+}
@@ -0,0 +1,30 @@
+/*
+Package distributions generates pseudo random numbers with non uniform distributions, consuming
+the values of a generator directly with Uint64(), without going through rand.Rand.
+
+Every function takes a Source, that is implemented by all the generators of the xorshift sub packages
+(and by the sources of math/rand/v2), e.g.:
+
+	x := xoroshiro256plusplus.NewSource(1)
+	v := distributions.NormFloat64(x)
+
+NOTE: the functions are concurrency-safe only if the Source is.
+*/
+package distributions
+
+import "github.com/vpxyz/xorshift/internal"
+
+// Source is the generator used by the distributions. It's the Source interface of math/rand/v2.
+type Source interface {
+	Uint64() uint64
+}
+
+// uniform returns a pseudo-random number in [0,1), from the upper 53 bits of the generated value.
+func uniform(src Source) float64 {
+	return internal.Float64(src.Uint64())
+}
+
+// uniformOpen returns a pseudo-random number in (0,1), so it can be passed to math.Log.
+func uniformOpen(src Source) float64 {
+	return internal.Float64Open(src.Uint64())
+}
@@ -0,0 +1,228 @@
+package distributions
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// ErrInvalidWeights the weights are empty, negative, not finite or all zero.
+var ErrInvalidWeights = errors.New("distributions: invalid weights")
+
+// checkWeights returns the sum of the weights, or ErrInvalidWeights.
+func checkWeights(weights []float64) (float64, error) {
+	var sum float64
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return 0, ErrInvalidWeights
+		}
+		sum += w
+	}
+	if !(sum > 0) || math.IsInf(sum, 1) {
+		return 0, ErrInvalidWeights
+	}
+	return sum, nil
+}
+
+// Alias samples the indexes of a fixed set of weights in constant time, with the alias method of Walker,
+// built with Vose's algorithm ("A linear algorithm for generating random numbers with a given distribution", 1991).
+// Every index i gets a bucket, split between i and an alias index: a draw uses a single Uint64(), whose product
+// with the number of buckets gives the bucket in the upper 64 bits, and a uniform threshold inside the bucket
+// in the lower 64 bits. An Alias is never modified after its creation, so it can be shared by many goroutines.
+type Alias struct {
+	threshold []uint64 // the part of the bucket that belongs to its own index, scaled by 2^64
+	alias     []int
+}
+
+// NewAlias return a new Alias for the given weights, the index i is drawn with probability weights[i]/sum(weights).
+// It takes O(len(weights)) time, and returns ErrInvalidWeights if the weights are empty, negative, not finite or all zero.
+func NewAlias(weights []float64) (*Alias, error) {
+	sum, err := checkWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(weights)
+	a := &Alias{threshold: make([]uint64, n), alias: make([]int, n)}
+	p := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		p[i] = w * float64(n) / sum
+		if p[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		a.threshold[s] = scale64(p[s])
+		a.alias[s] = l
+		p[l] -= 1 - p[s]
+		if p[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// the remaining buckets are full, up to rounding errors
+	for _, i := range append(small, large...) {
+		a.threshold[i] = math.MaxUint64
+		a.alias[i] = i
+	}
+	return a, nil
+}
+
+// scale64 returns p*2^64 as uint64, for p in [0, 1).
+func scale64(p float64) uint64 {
+	if p >= 1 {
+		return math.MaxUint64
+	}
+	return uint64(p * 0x1.0p64)
+}
+
+// Len returns the number of weights.
+func (a *Alias) Len() int {
+	return len(a.alias)
+}
+
+// Draw returns a pseudo random index, with the probability given by the weights.
+func (a *Alias) Draw(src Source) int {
+	i, t := bits.Mul64(src.Uint64(), uint64(len(a.alias)))
+	if t < a.threshold[i] {
+		return int(i)
+	}
+	return a.alias[i]
+}
+
+// Fenwick samples the indexes of a set of weights that can change, using a Fenwick tree (binary indexed tree)
+// of the partial sums of the weights: Draw takes O(log n) time, and Set O(log^2 n), because it recomputes
+// the sums that hold the weight from their parts instead of adding the difference, that would cancel out
+// the small weights next to a large one. Not concurrency-safe: Set modifies the tree.
+type Fenwick struct {
+	weights []float64
+	tree    []float64 // tree[i] is the sum of the weights from i-(i&-i) to i-1
+	mask    int       // the highest power of two <= len(weights)
+	nonzero int       // the number of nonzero weights
+}
+
+// NewFenwick return a new Fenwick for the given weights, the index i is drawn with probability weights[i]/sum(weights).
+// It takes O(len(weights)) time, and returns ErrInvalidWeights if the weights are empty, negative, not finite or all zero.
+func NewFenwick(weights []float64) (*Fenwick, error) {
+	f := &Fenwick{}
+	if err := f.Reset(weights); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reset replaces all the weights, rebuilding the tree in O(len(weights)) time.
+// It returns ErrInvalidWeights, leaving the Fenwick unchanged, if the weights are empty, negative, not finite or all zero.
+func (f *Fenwick) Reset(weights []float64) error {
+	if _, err := checkWeights(weights); err != nil {
+		return err
+	}
+	n := len(weights)
+	f.weights = append(f.weights[:0], weights...)
+	f.tree = make([]float64, n+1)
+	copy(f.tree[1:], weights)
+	for i := 1; i <= n; i++ {
+		if j := i + i&-i; j <= n {
+			f.tree[j] += f.tree[i]
+		}
+	}
+	f.mask = 1 << uint(bits.Len(uint(n))-1)
+	f.nonzero = 0
+	for _, w := range weights {
+		if w > 0 {
+			f.nonzero++
+		}
+	}
+	return nil
+}
+
+// Len returns the number of weights.
+func (f *Fenwick) Len() int {
+	return len(f.weights)
+}
+
+// Weight returns the weight of the index i.
+func (f *Fenwick) Weight(i int) float64 {
+	return f.weights[i]
+}
+
+// Total returns the sum of the weights, exactly zero if all the weights are zero.
+func (f *Fenwick) Total() float64 {
+	if f.nonzero == 0 {
+		return 0
+	}
+	var sum float64
+	for i := len(f.weights); i > 0; i -= i & -i {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// Set changes the weight of the index i. It panics if i is out of range, and returns ErrInvalidWeights,
+// leaving the weight unchanged, if w is negative or not finite, or if the sum of the weights would overflow.
+// If all the weights are zero, Draw panics.
+func (f *Fenwick) Set(i int, w float64) error {
+	if !(w >= 0) || math.IsInf(w, 1) {
+		return ErrInvalidWeights
+	}
+	old := f.weights[i]
+	f.update(i, w)
+	if math.IsInf(f.Total(), 1) {
+		f.update(i, old)
+		return ErrInvalidWeights
+	}
+	switch {
+	case old == 0 && w > 0:
+		f.nonzero++
+	case old > 0 && w == 0:
+		f.nonzero--
+	}
+	return nil
+}
+
+// update sets the weight of the index i and recomputes the sums that hold it.
+func (f *Fenwick) update(i int, w float64) {
+	f.weights[i] = w
+	for j := i + 1; j < len(f.tree); j += j & -j {
+		// the same order of additions of Reset
+		sum := f.weights[j-1]
+		for k := (j & -j) >> 1; k > 0; k >>= 1 {
+			sum += f.tree[j-k]
+		}
+		f.tree[j] = sum
+	}
+}
+
+// Draw returns a pseudo random index, with the probability given by the current weights.
+func (f *Fenwick) Draw(src Source) int {
+	if f.nonzero == 0 {
+		panic("distributions: Draw with all the weights zero")
+	}
+	u := uniform(src) * f.Total()
+
+	// find the first index whose partial sum exceeds u
+	pos := 0
+	for step := f.mask; step > 0; step >>= 1 {
+		if next := pos + step; next < len(f.tree) && f.tree[next] <= u {
+			pos = next
+			u -= f.tree[next]
+		}
+	}
+	// rounding errors could skip the last indexes, or land on a zero weight
+	if pos >= len(f.weights) {
+		pos = len(f.weights) - 1
+	}
+	for pos > 0 && f.weights[pos] == 0 {
+		pos--
+	}
+	for pos < len(f.weights)-1 && f.weights[pos] == 0 {
+		pos++
+	}
+	return pos
+}
@@ -0,0 +1,112 @@
+package distributions
+
+import "math"
+
+// The ziggurat method of Marsaglia and Tsang ("The Ziggurat Method for Generating Random Variables", 2000),
+// with 256 layers of equal area v: layer 0 is the base strip, with the tail beyond r, and layer i, 1 <= i <= 255,
+// is the rectangle of width x[i] between f(x[i]) and f(x[i+1]). A single Uint64() gives the layer, from the
+// bits 3 to 10, and the position inside it, from the upper 53 bits, so most of the samples cost a single
+// generated value and a multiplication. The lowest 3 bits are skipped, because the lower bits of the "+"
+// generators are weak. Unlike math/rand the tables are computed when the package is loaded.
+
+const (
+	zigLayers = 256
+
+	// normal distribution: start of the tail and area of every layer
+	zigNormR = 3.6541528853610088
+	zigNormV = 0.00492867323397465524494
+
+	// exponential distribution: start of the tail and area of every layer
+	zigExpR = 7.69711747013104972
+	zigExpV = 0.0039496598225815571993
+)
+
+// zigNormX and zigExpX hold the width of the layers, x[0] is the width of a rectangle of area v
+// and height f(r), x[256] is 0. zigNormF and zigExpF hold f(x[i]).
+var (
+	zigNormX, zigNormF [zigLayers + 1]float64
+	zigExpX, zigExpF   [zigLayers + 1]float64
+)
+
+func init() {
+	zigTables(&zigNormX, &zigNormF, zigNormR, zigNormV, normPDF, func(y float64) float64 {
+		return math.Sqrt(-2 * math.Log(y))
+	})
+	zigTables(&zigExpX, &zigExpF, zigExpR, zigExpV, expPDF, func(y float64) float64 {
+		return -math.Log(y)
+	})
+}
+
+// zigTables computes the ziggurat tables for the decreasing function f, with inverse finv.
+func zigTables(x, fx *[zigLayers + 1]float64, r, v float64, f, finv func(float64) float64) {
+	x[0] = v / f(r)
+	x[1] = r
+	for i := 2; i < zigLayers; i++ {
+		x[i] = finv(v/x[i-1] + f(x[i-1]))
+	}
+	x[zigLayers] = 0
+	for i := range x {
+		fx[i] = f(x[i])
+	}
+}
+
+// normPDF is the density of the standard normal distribution, without the normalization constant.
+func normPDF(x float64) float64 {
+	return math.Exp(-x * x / 2)
+}
+
+// expPDF is the density of the exponential distribution with rate 1.
+func expPDF(x float64) float64 {
+	return math.Exp(-x)
+}
+
+// NormFloat64 returns a normally distributed float64 in the range [-math.MaxFloat64, +math.MaxFloat64],
+// with mean 0 and standard deviation 1. For a different distribution use NormFloat64(src) * stddev + mean.
+func NormFloat64(src Source) float64 {
+	for {
+		r := src.Uint64()
+		i := r >> 3 & 0xff
+		u := float64(r>>11)*0x1.0p-52 - 1 // in [-1, 1)
+		x := u * zigNormX[i]
+		if math.Abs(x) < zigNormX[i+1] {
+			return x
+		}
+		if i == 0 {
+			// from the tail, with Marsaglia's method
+			for {
+				x = -math.Log(uniformOpen(src)) / zigNormR
+				y := -math.Log(uniformOpen(src))
+				if 2*y >= x*x {
+					break
+				}
+			}
+			if u < 0 {
+				return -zigNormR - x
+			}
+			return zigNormR + x
+		}
+		if zigNormF[i+1]+(zigNormF[i]-zigNormF[i+1])*uniform(src) < normPDF(x) {
+			return x
+		}
+	}
+}
+
+// ExpFloat64 returns an exponentially distributed float64 in the range [0, +math.MaxFloat64],
+// with rate 1 and mean 1. For a different rate use ExpFloat64(src) / rate.
+func ExpFloat64(src Source) float64 {
+	for {
+		r := src.Uint64()
+		i := r >> 3 & 0xff
+		x := float64(r>>11) * 0x1.0p-53 * zigExpX[i]
+		if x < zigExpX[i+1] {
+			return x
+		}
+		if i == 0 {
+			// the tail is exponential too
+			return zigExpR - math.Log(uniformOpen(src))
+		}
+		if zigExpF[i+1]+(zigExpF[i]-zigExpF[i+1])*uniform(src) < expPDF(x) {
+			return x
+		}
+	}
+}
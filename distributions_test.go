@@ -0,0 +1,114 @@
+package xorshift
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/vpxyz/xorshift/distributions"
+	"github.com/vpxyz/xorshift/xoroshiro128plus"
+	"github.com/vpxyz/xorshift/xoroshiro256plusplus"
+)
+
+// moments returns mean, variance, skewness and excess kurtosis of n values generated by f.
+func moments(n int, f func() float64) (mean, variance, skew, kurt float64) {
+	var s1, s2, s3, s4 float64
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = f()
+		s1 += v[i]
+	}
+	mean = s1 / float64(n)
+	for _, x := range v {
+		d := x - mean
+		s2 += d * d
+		s3 += d * d * d
+		s4 += d * d * d * d
+	}
+	variance = s2 / float64(n)
+	skew = s3 / float64(n) / math.Pow(variance, 1.5)
+	kurt = s4/float64(n)/(variance*variance) - 3
+	return
+}
+
+// checkMoment reports an error if got differs from want by more than tol.
+func checkMoment(t *testing.T, name, moment string, got, want, tol float64) {
+	t.Helper()
+	if math.Abs(got-want) > tol {
+		t.Errorf("%s: %s = %.4f, expected %.4f ± %.4f", name, moment, got, want, tol)
+	}
+}
+
+func TestNormFloat64(t *testing.T) {
+	const n = 1000000
+	x := xoroshiro256plusplus.NewSource(SEED)
+	var tail int
+	mean, variance, skew, kurt := moments(n, func() float64 {
+		v := distributions.NormFloat64(x)
+		if math.Abs(v) > 3.6541528853610088 {
+			tail++
+		}
+		return v
+	})
+	// the tolerances are about 5 standard errors
+	checkMoment(t, "NormFloat64", "mean", mean, 0, 0.005)
+	checkMoment(t, "NormFloat64", "variance", variance, 1, 0.007)
+	checkMoment(t, "NormFloat64", "skewness", skew, 0, 0.012)
+	checkMoment(t, "NormFloat64", "excess kurtosis", kurt, 0, 0.025)
+	// P(|x| > r) = 2.58e-4, the tail of the ziggurat
+	checkMoment(t, "NormFloat64", "tail fraction", float64(tail)/n, 2.58e-4, 0.00008)
+}
+
+func TestExpFloat64(t *testing.T) {
+	const n = 1000000
+	x := xoroshiro128plus.NewSource(SEED)
+	var tail int
+	mean, variance, skew, kurt := moments(n, func() float64 {
+		v := distributions.ExpFloat64(x)
+		if v < 0 {
+			t.Fatalf("ExpFloat64 = %v, negative", v)
+		}
+		if v > 7.69711747013104972 {
+			tail++
+		}
+		return v
+	})
+	checkMoment(t, "ExpFloat64", "mean", mean, 1, 0.005)
+	checkMoment(t, "ExpFloat64", "variance", variance, 1, 0.02)
+	checkMoment(t, "ExpFloat64", "skewness", skew, 2, 0.1)
+	checkMoment(t, "ExpFloat64", "excess kurtosis", kurt, 6, 1)
+	// P(x > r) = exp(-r) = 4.54e-4, the tail of the ziggurat
+	checkMoment(t, "ExpFloat64", "tail fraction", float64(tail)/n, 4.54e-4, 0.0001)
+}
+
+func BenchmarkNormFloat64(b *testing.B) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = distributions.NormFloat64(x)
+	}
+}
+
+func BenchmarkMathRandNormFloat64(b *testing.B) {
+	r := rand.New(xoroshiro256plusplus.NewSource(SEED))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = r.NormFloat64()
+	}
+}
+
+func BenchmarkExpFloat64(b *testing.B) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = distributions.ExpFloat64(x)
+	}
+}
+
+func BenchmarkMathRandExpFloat64(b *testing.B) {
+	r := rand.New(xoroshiro256plusplus.NewSource(SEED))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = r.ExpFloat64()
+	}
+}
@@ -10,11 +10,61 @@ Splitmix64 generator is a fixed-increment version of Java 8's SplittableRandom g
 It's based on the work of Sebastiano Vigna (http://xoroshiro.di.unimi.it/).
 
 All the generators implements rand.Source64 interface and can be used
-as a drop-in replacement for rand.New() parameter.
+as a drop-in replacement for rand.New() parameter. They implement the Source interface of
+math/rand/v2 too, so they can be passed as is to rand.New() of math/rand/v2. NewSource wraps
+a math/rand/v2 source (e.g. rand.PCG), so that it can be used with both packages.
+
+Uint64n, Uint32n and Intn generate unbiased integers in [0, n) straight from any generator, with
+Lemire's nearly divisionless method, faster than rand.Rand's Intn. On top of them Shuffle, Perm and
+PartialShuffle (k out of n elements) don't allocate, Sample (Floyd's algorithm for distinct integers)
+allocates O(len(dst)) memory, and all of them follow documented algorithms, so their output is reproducible. Reservoir (Algorithm L) and
+WeightedReservoir (A-ExpJ) sample k items from streams of unknown length, skipping the items that
+can't enter the sample with a few calls to the generator.
+Every generator has Float64() and Float32() functions, that use the upper bits of Uint64() as suggested
+by Vigna (the lower bits of the "+" generators are weak), e.g. (x >> 11) * 0x1.0p-53, and the variants
+for the open interval (0,1) and the closed interval [0,1].
+Float64Full, built on any generator, reaches every float64 in [0,1) with the right probability,
+even the tiny ones that Float64() rounds to a multiple of 2^-53, consuming more values when needed.
+
+The distributions package generates non uniform variates straight from any generator, e.g. the
+ziggurat NormFloat64 and ExpFloat64, without the rand.Rand overhead, and the exact discrete
+distributions Poisson, Binomial, Geometric and NegativeBinomial, and the continuous Gamma, Beta,
+ChiSquared, StudentT, Cauchy, LogNormal, Weibull and Pareto. For weighted discrete sampling it has
+an alias table (Alias), with O(1) draws from a single Uint64(), and a Fenwick tree (Fenwick) for weights that change.
 
 Some generators have a Jump() function that is equivalent to call the generator many times.
+The xoroshiro generators have even a LongJump() function, with a longer jump distance, that can be used
+together with Jump() to generate a two-level hierarchy of non-overlapping streams for distributed computations.
+StreamFactory wraps any generator with Jump() and hands out its non-overlapping streams; the n-th stream
+is reached with JumpN(n) in O(log n) time, so a computation can be resumed at any stream index.
+All the generators but splitmix64 are linear over GF(2), so they have an Advance(n) function too,
+that moves the generator ahead of exactly n steps computing x^n mod the characteristic polynomial of the generator.
+Every generator has a Prev() function, that undoes the last call to Uint64() and returns the value it produced.
+
+The exact state of every generator can be saved and restored with MarshalBinary/UnmarshalBinary
+or MarshalText/UnmarshalText (and so embedded in JSON documents), e.g. to checkpoint a simulation.
+The text form is the name of the algorithm followed by the state words, like
+"xoroshiro256**:0123456789abcdef,...", plus the position for xorshift1024* and xorshift4096*.
+
+Besides Seed(int64), every generator can be seeded from an arbitrary byte string, like a string ID
+or a 128/256-bit key, with SeedBytes or NewSourceFromKey. The key is absorbed 64 bits at a time in a
+splitmix64 stream and mixed through the whole state with invertible steps, so every bit of the key
+counts, distinct keys of the same length up to the state size give distinct states, and the same key
+gives always the same state across releases.
+
+To seed many independent generators from a single experiment seed (per worker, per replica, ...)
+use the seedseq package, an implementation of NumPy's SeedSequence: a seed sequence can Spawn
+any number of children, deterministically, and every generator can be seeded from one of them
+with SeedFrom or NewSourceFromSeedSequence.
+
+NOTE: Not concurrency-safe! You can wrap a generator with NewLockedSource, that serializes the calls with a mutex:
+
+	r := rand.New(xorshift.NewLockedSource(xoroshiro256starstar.NewSource(1)))
 
-NOTE: Not concurrency-safe! You can wrap generator with a monitor goroutine, for e.g.
+ShardedSource scales better with many goroutines: it keeps a pool of generators, one per P, each one
+a different stream of a StreamFactory, so the shards never overlap.
+Or you can wrap a generator with a monitor goroutine: NewMonitor starts a goroutine that owns the generator,
+and serves batches of values over a channel; Seed and Jump requests go through the goroutine too.
 
 */
 package xorshift
@@ -0,0 +1,171 @@
+package xorshift
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vpxyz/xorshift/xoroshiro128starstar"
+	"github.com/vpxyz/xorshift/xorshift1024star"
+	"github.com/vpxyz/xorshift/xorshift4096star"
+)
+
+// newLike returns a new, zero valued, generator of the same type of x.
+func newLike(x XorShift) XorShift {
+	return reflect.New(reflect.TypeOf(x).Elem()).Interface().(XorShift)
+}
+
+func TestMarshalBinary(t *testing.T) {
+	for _, c := range conformanceCases {
+		x := c.load(c.state)
+		for i := 0; i < 21; i++ { // move the position of xorshift1024* and xorshift4096*
+			x.Uint64()
+		}
+
+		data, err := x.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Errorf("%s: MarshalBinary() error: %v", c.name, err)
+			continue
+		}
+
+		y := newLike(x)
+		if err := y.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+			t.Errorf("%s: UnmarshalBinary() error: %v", c.name, err)
+			continue
+		}
+		if !sameOutput(x, y, 100) {
+			t.Errorf("%s: the decoded generator differs from the encoded one", c.name)
+		}
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	for i, c := range conformanceCases {
+		x := c.load(c.state)
+		data, _ := x.(encoding.BinaryMarshaler).MarshalBinary()
+		u := newLike(x).(encoding.BinaryUnmarshaler)
+
+		other := conformanceCases[(i+1)%len(conformanceCases)]
+		odata, _ := other.load(other.state).(encoding.BinaryMarshaler).MarshalBinary()
+		if err := u.UnmarshalBinary(odata); !errors.Is(err, ErrWrongGenerator) {
+			t.Errorf("%s: decoding a %s state, error %v, want %v", c.name, other.name, err, ErrWrongGenerator)
+		}
+
+		if err := u.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("%s: decoding a truncated state, error %v, want %v", c.name, err, ErrInvalidState)
+		}
+
+		version := append([]byte{}, data...)
+		version[0]++
+		if err := u.UnmarshalBinary(version); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("%s: decoding an unknown version, error %v, want %v", c.name, err, ErrInvalidState)
+		}
+
+		if c.name == "xorshift1024*" || c.name == "xorshift4096*" {
+			position := append([]byte{}, data...)
+			position[len(position)-1] = 100
+			if err := u.UnmarshalBinary(position); !errors.Is(err, ErrInvalidState) {
+				t.Errorf("%s: decoding a position out of range, error %v, want %v", c.name, err, ErrInvalidState)
+			}
+		}
+
+		zero := append([]byte{}, data...)
+		for j := 2 + int(zero[1]); j < len(zero); j++ {
+			zero[j] = 0
+		}
+		err := u.UnmarshalBinary(zero)
+		if c.name == "splitmix64" {
+			if err != nil {
+				t.Errorf("%s: decoding a zero state, error %v", c.name, err)
+			}
+		} else if !errors.Is(err, ErrZeroState) {
+			t.Errorf("%s: decoding a zero state, error %v, want %v", c.name, err, ErrZeroState)
+		}
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	for _, c := range conformanceCases {
+		x := c.load(c.state)
+		for i := 0; i < 21; i++ {
+			x.Uint64()
+		}
+
+		text, err := x.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			t.Errorf("%s: MarshalText() error: %v", c.name, err)
+			continue
+		}
+
+		y := newLike(x)
+		if err := y.(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+			t.Errorf("%s: UnmarshalText(%q) error: %v", c.name, text, err)
+			continue
+		}
+		if !sameOutput(x, y, 100) {
+			t.Errorf("%s: the decoded generator differs from the encoded one", c.name)
+		}
+	}
+}
+
+func TestMarshalTextFormat(t *testing.T) {
+	x := &xoroshiro128starstar.XoroShiro128StarStar{}
+	x.SetState([2]uint64{0x0123456789abcdef, 42})
+	text, _ := x.MarshalText()
+	if want := "xoroshiro128**:0123456789abcdef,000000000000002a"; string(text) != want {
+		t.Errorf("MarshalText() = %q, want %q", text, want)
+	}
+
+	y := &xorshift1024star.XorShift1024Star{}
+	y.SetState([16]uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	y.Uint64()
+	y.Uint64()
+	text, _ = y.MarshalText()
+	if !strings.HasPrefix(string(text), "xorshift1024*:0000000000000001,") || !strings.HasSuffix(string(text), ":2") {
+		t.Errorf("MarshalText() = %q, want the name, the words and the position 2", text)
+	}
+
+	for _, text := range []string{
+		"xoroshiro128**:0123456789abcdef",
+		"xoroshiro128**:0123456789abcdef,0123456789abcdef:1",
+		"xoroshiro128**:0123456789abcdef,xyz",
+		"xoroshiro128**:0000000000000000,0000000000000000",
+		"xoroshiro128+:0123456789abcdef,0123456789abcdef",
+	} {
+		if err := x.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) no error", text)
+		}
+	}
+	for _, p := range []string{":16", ":+3", ":-1", ": 3", ":"} {
+		if err := y.UnmarshalText([]byte(strings.Replace(string(text), ":2", p, 1))); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("UnmarshalText() with position %q, error %v, want %v", p[1:], err, ErrInvalidState)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type job struct {
+		Name string
+		Rand *xorshift4096star.XorShift4096Star
+	}
+
+	in := job{Name: "checkpoint", Rand: xorshift4096star.NewSource(SEED)}
+	for i := 0; i < 77; i++ {
+		in.Rand.Uint64()
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+
+	var out job
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if !sameOutput(in.Rand, out.Rand, 100) {
+		t.Errorf("the generator decoded from JSON differs from the encoded one")
+	}
+}
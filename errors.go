@@ -0,0 +1,15 @@
+package xorshift
+
+import "github.com/vpxyz/xorshift/internal"
+
+// The errors returned by the sub packages, when decoding or setting a generator state.
+var (
+	// ErrZeroState the state is everywhere zero, the generator would produce only zeros.
+	ErrZeroState = internal.ErrZeroState
+
+	// ErrWrongGenerator the encoded state belongs to a different generator.
+	ErrWrongGenerator = internal.ErrWrongGenerator
+
+	// ErrInvalidState the encoded state is malformed.
+	ErrInvalidState = internal.ErrInvalidState
+)
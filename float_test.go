@@ -0,0 +1,98 @@
+package xorshift
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/xoroshiro256plus"
+)
+
+// floater is implemented by all the generators.
+type floater interface {
+	XorShift
+	Float64() float64
+	Float64Open() float64
+	Float64Closed() float64
+	Float32() float32
+	Float32Open() float32
+	Float32Closed() float32
+}
+
+func TestFloat(t *testing.T) {
+	for _, c := range conformanceCases {
+		x, ok := c.load(c.state).(floater)
+		if !ok {
+			t.Errorf("%s: does not implement the Float functions", c.name)
+			continue
+		}
+		y := c.load(c.state)
+
+		for i := 0; i < 1000; i++ {
+			if f, w := x.Float64(), float64(y.Uint64()>>11)/(1<<53); f != w {
+				t.Fatalf("%s: Float64() = %v, expected %v", c.name, f, w)
+			}
+			if f, w := x.Float32(), float32(y.Uint64()>>40)/(1<<24); f != w {
+				t.Fatalf("%s: Float32() = %v, expected %v", c.name, f, w)
+			}
+			if f := x.Float64Open(); f <= 0 || f >= 1 {
+				t.Fatalf("%s: Float64Open() = %v", c.name, f)
+			}
+			if f := x.Float64Closed(); f < 0 || f > 1 {
+				t.Fatalf("%s: Float64Closed() = %v", c.name, f)
+			}
+			if f := x.Float32Open(); f <= 0 || f >= 1 {
+				t.Fatalf("%s: Float32Open() = %v", c.name, f)
+			}
+			if f := x.Float32Closed(); f < 0 || f > 1 {
+				t.Fatalf("%s: Float32Closed() = %v", c.name, f)
+			}
+			y.Uint64()
+			y.Uint64()
+			y.Uint64()
+			y.Uint64()
+		}
+	}
+}
+
+func TestFloatBounds(t *testing.T) {
+	const max = math.MaxUint64
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"Float64(0)", internal.Float64(0), 0},
+		{"Float64(max)", internal.Float64(max), 1 - 0x1p-53},
+		{"Float64Open(0)", internal.Float64Open(0), 0x1p-53},
+		{"Float64Open(max)", internal.Float64Open(max), 1 - 0x1p-53},
+		{"Float64Closed(0)", internal.Float64Closed(0), 0},
+		{"Float64Closed(max)", internal.Float64Closed(max), 1},
+		{"Float32(0)", float64(internal.Float32(0)), 0},
+		{"Float32(max)", float64(internal.Float32(max)), 1 - 0x1p-24},
+		{"Float32Open(0)", float64(internal.Float32Open(0)), 0x1p-24},
+		{"Float32Open(max)", float64(internal.Float32Open(max)), 1 - 0x1p-24},
+		{"Float32Closed(0)", float64(internal.Float32Closed(0)), 0},
+		{"Float32Closed(max)", float64(internal.Float32Closed(max)), 1},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s = %v, expected %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func BenchmarkFloat64(b *testing.B) {
+	x := xoroshiro256plus.NewSource(SEED)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = x.Float64()
+	}
+}
+
+func BenchmarkMathRandFloat64(b *testing.B) {
+	r := rand.New(xoroshiro256plus.NewSource(SEED))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = r.Float64()
+	}
+}
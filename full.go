@@ -0,0 +1,46 @@
+package xorshift
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Float64Full returns a pseudo-random number in [0.0,1.0) generated by x, reaching every float64 of the interval,
+// subnormals included, as in Downey's "Generating Pseudo-random Floating-Point Values" (2007).
+// The result is a uniform real number in [0,1) rounded down to a float64, so every float64 d is returned
+// with probability equal to the distance from d to the next float64: e.g. the values below 2^-53, that
+// Float64() can return only as 0, get their full 52 bits of mantissa.
+//
+// The exponent is given by the number of leading zero bits of the generated values, so more than one
+// call to Uint64() is needed only for values less than 2^-64 (or, for the mantissa, less than 2^-12):
+// on average Float64Full costs about one call to Uint64().
+func Float64Full(x XorShift) float64 {
+	exp := -1 // the result is in [2^exp, 2^(exp+1))
+	r := x.Uint64()
+	for r == 0 {
+		exp -= 64
+		if exp < -1074 {
+			return 0
+		}
+		r = x.Uint64()
+	}
+	lz := bits.LeadingZeros64(r)
+	exp -= lz
+	if exp < -1074 {
+		return 0
+	}
+
+	// the mantissa is given by the bits after the leading one, if they're enough
+	var m uint64
+	if lz <= 11 {
+		m = r << uint(lz+1) >> 12
+	} else {
+		m = x.Uint64() >> 12
+	}
+
+	if exp >= -1022 {
+		return math.Float64frombits(uint64(exp+1023)<<52 | m)
+	}
+	// subnormal: the value is an integer multiple of 2^-1074, drop the bits below it
+	return math.Float64frombits((1<<52 | m) >> uint(-1022-exp))
+}
@@ -0,0 +1,90 @@
+package xorshift
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+)
+
+// scripted is a XorShift that returns the given values.
+type scripted struct {
+	v []uint64
+}
+
+func (s *scripted) Seed(seed int64) {}
+
+func (s *scripted) Uint64() uint64 {
+	v := s.v[0]
+	s.v = s.v[1:]
+	return v
+}
+
+func TestFloat64Full(t *testing.T) {
+	zeros := func(n int, v ...uint64) *scripted {
+		return &scripted{append(make([]uint64, n), v...)}
+	}
+	const m = 0x123456789abcd // a 52 bits mantissa
+	for _, c := range []struct {
+		name string
+		x    *scripted
+		want float64
+	}{
+		{"max", zeros(0, math.MaxUint64), 1 - 0x1p-53},
+		{"half", zeros(0, 1<<63), 0.5},
+		{"mantissa from the same word", zeros(0, 1<<62|m<<10), math.Ldexp(1+m*0x1p-52, -2)},
+		{"mantissa from the next word", zeros(0, 1<<50, m<<12|0xfff), math.Ldexp(1+m*0x1p-52, -14)},
+		{"after a zero word", zeros(1, 1<<10, m<<12), math.Ldexp(1+m*0x1p-52, -118)},
+		{"smallest normal", zeros(15, 1<<2, 0), 0x1p-1022},
+		{"subnormal", zeros(16, 1<<63), 0x1p-1025},
+		{"subnormal rounded down", zeros(16, 1<<40, m<<12), math.Float64frombits((1<<52 | m) >> 26)},
+		{"smallest subnormal", zeros(16, 1<<14, 0), 0x1p-1074},
+		{"underflow", zeros(16, 1<<13), 0},
+		{"zero", zeros(17), 0},
+	} {
+		if got := Float64Full(c.x); got != c.want {
+			t.Errorf("%s: Float64Full = %g, expected %g", c.name, got, c.want)
+		}
+	}
+}
+
+func TestFloat64FullDistribution(t *testing.T) {
+	const n = 100000
+	x := xoroshiro256starstar.NewSource(SEED)
+	var sum float64
+	var small, odd int
+	for i := 0; i < n; i++ {
+		f := Float64Full(x)
+		if f < 0 || f >= 1 {
+			t.Fatalf("Float64Full = %v, out of [0, 1)", f)
+		}
+		sum += f
+		if f < 0x1p-5 {
+			small++
+			if math.Float64bits(f)&1 != 0 {
+				odd++
+			}
+		}
+	}
+
+	// the mean is 1/2 with standard deviation sqrt(1/12/n) ~ 0.0009
+	if mean := sum / n; math.Abs(mean-0.5) > 0.005 {
+		t.Errorf("mean = %v, expected 0.5", mean)
+	}
+	// P(f < 2^-5) = 1/32, n/32 = 3125 values with standard deviation ~ 55
+	if math.Abs(float64(small)-n/32) > 300 {
+		t.Errorf("%d values less than 2^-5, expected about %d", small, n/32)
+	}
+	// the small values have full precision, so the last bit of the mantissa is random
+	if math.Abs(float64(odd)-float64(small)/2) > 200 {
+		t.Errorf("%d odd mantissas out of %d small values, expected about half", odd, small)
+	}
+}
+
+func BenchmarkFloat64Full(b *testing.B) {
+	x := xoroshiro256starstar.NewSource(SEED)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Float64Full(x)
+	}
+}
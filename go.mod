@@ -1,3 +1,3 @@
 module github.com/vpxyz/xorshift
 
-go 1.9
+go 1.22
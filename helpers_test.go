@@ -0,0 +1,14 @@
+package xorshift
+
+import "testing"
+
+// expectPanic reports an error, naming the case, if f doesn't panic.
+func expectPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: no panic", name)
+		}
+	}()
+	f()
+}
@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// binaryVersion is the version of the binary encoding of the states.
+const binaryVersion = 1
+
+var (
+	// ErrZeroState the state is everywhere zero, the generator would produce only zeros.
+	ErrZeroState = errors.New("xorshift: the state must not be everywhere zero")
+
+	// ErrWrongGenerator the encoded state belongs to a different generator.
+	ErrWrongGenerator = errors.New("xorshift: the state belongs to a different generator")
+
+	// ErrInvalidState the encoded state is malformed.
+	ErrInvalidState = errors.New("xorshift: invalid state encoding")
+)
+
+// Codec describes the state of a generator for the encoding functions.
+type Codec struct {
+	Name      string // name of the algorithm, stored in the encoded state
+	Words     int    // number of 64-bit words of the state
+	Position  bool   // the state has a rotating index p, 0 <= p < Words
+	AllowZero bool   // an everywhere zero state is a valid state
+}
+
+// MarshalBinary returns the binary encoding of the state s with position p: the version of the encoding,
+// the length and the name of the algorithm, the state words in big-endian order and, for the
+// generators that have one, the position in one byte.
+func (c *Codec) MarshalBinary(s []uint64, p int) []byte {
+	b := make([]byte, 0, 2+len(c.Name)+8*len(s)+1)
+	b = append(b, binaryVersion, byte(len(c.Name)))
+	b = append(b, c.Name...)
+	for _, w := range s {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], w)
+		b = append(b, buf[:]...)
+	}
+	if c.Position {
+		b = append(b, byte(p))
+	}
+	return b
+}
+
+// UnmarshalBinary decodes into s the binary encoding produced by MarshalBinary and returns the position.
+func (c *Codec) UnmarshalBinary(data []byte, s []uint64) (int, error) {
+	if len(data) < 2 {
+		return 0, fmt.Errorf("%w: too short", ErrInvalidState)
+	}
+	if data[0] != binaryVersion {
+		return 0, fmt.Errorf("%w: unknown version %d", ErrInvalidState, data[0])
+	}
+	n := int(data[1])
+	if len(data) < 2+n {
+		return 0, fmt.Errorf("%w: too short", ErrInvalidState)
+	}
+	if name := string(data[2 : 2+n]); name != c.Name {
+		return 0, fmt.Errorf("%w: %q, want %q", ErrWrongGenerator, name, c.Name)
+	}
+	data = data[2+n:]
+
+	size := 8 * c.Words
+	if c.Position {
+		size++
+	}
+	if len(data) != size {
+		return 0, fmt.Errorf("%w: %d bytes of state, want %d", ErrInvalidState, len(data), size)
+	}
+	for i := range s {
+		s[i] = binary.BigEndian.Uint64(data[8*i:])
+	}
+
+	p := 0
+	if c.Position {
+		p = int(data[size-1])
+	}
+	return p, c.check(s, p)
+}
+
+// MarshalText returns the text encoding of the state s with position p: the name of the algorithm,
+// a colon, the state words as comma-separated 16 digits hex numbers and, for the generators that
+// have one, a colon and the position, e.g. "xorshift1024*:0123456789abcdef,...,fedcba9876543210:3".
+func (c *Codec) MarshalText(s []uint64, p int) []byte {
+	b := make([]byte, 0, len(c.Name)+17*len(s)+4)
+	b = append(b, c.Name...)
+	for i, w := range s {
+		if i == 0 {
+			b = append(b, ':')
+		} else {
+			b = append(b, ',')
+		}
+		b = append(b, fmt.Sprintf("%016x", w)...)
+	}
+	if c.Position {
+		b = append(b, ':')
+		b = strconv.AppendInt(b, int64(p), 10)
+	}
+	return b
+}
+
+// UnmarshalText decodes into s the text encoding produced by MarshalText and returns the position.
+func (c *Codec) UnmarshalText(text []byte, s []uint64) (int, error) {
+	fields := strings.Split(string(text), ":")
+	if fields[0] != c.Name {
+		return 0, fmt.Errorf("%w: %q, want %q", ErrWrongGenerator, fields[0], c.Name)
+	}
+	if c.Position && len(fields) != 3 || !c.Position && len(fields) != 2 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidState, text)
+	}
+
+	words := strings.Split(fields[1], ",")
+	if len(words) != c.Words {
+		return 0, fmt.Errorf("%w: %d words of state, want %d", ErrInvalidState, len(words), c.Words)
+	}
+	for i, w := range words {
+		v, err := strconv.ParseUint(w, 16, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidState, err)
+		}
+		s[i] = v
+	}
+
+	p := 0
+	if c.Position {
+		// only plain decimal digits, so that the encoding of a state is unique
+		v, err := strconv.ParseUint(fields[2], 10, 0)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidState, err)
+		}
+		p = int(v)
+	}
+	return p, c.check(s, p)
+}
+
+// check validates the decoded state.
+func (c *Codec) check(s []uint64, p int) error {
+	if p < 0 || p >= c.Words {
+		return fmt.Errorf("%w: position %d out of range", ErrInvalidState, p)
+	}
+	if !c.AllowZero && IsZero(s) {
+		return ErrZeroState
+	}
+	return nil
+}
+
+// IsZero reports whether the state s is everywhere zero.
+func IsZero(s []uint64) bool {
+	for _, w := range s {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package internal
+
+// Float conversions of a generated value. They use the upper bits, the strongest ones for the "+" generators,
+// as suggested by Vigna: the result is a multiple of 2^-53 (or 2^-24 for float32), without rounding.
+
+// Float64 converts v to a float64 in [0, 1), using the upper 53 bits.
+func Float64(v uint64) float64 {
+	return float64(v>>11) * 0x1.0p-53
+}
+
+// Float64Open converts v to a float64 in (0, 1), using the upper 52 bits: the result is an odd multiple of 2^-53.
+func Float64Open(v uint64) float64 {
+	return (float64(v>>12) + 0.5) * 0x1.0p-52
+}
+
+// Float64Closed converts v to a float64 in [0, 1], using the upper 53 bits: the result is a multiple of 1/(2^53-1).
+func Float64Closed(v uint64) float64 {
+	return float64(v>>11) / (1<<53 - 1)
+}
+
+// Float32 converts v to a float32 in [0, 1), using the upper 24 bits.
+func Float32(v uint64) float32 {
+	return float32(v>>40) * 0x1.0p-24
+}
+
+// Float32Open converts v to a float32 in (0, 1), using the upper 23 bits: the result is an odd multiple of 2^-24.
+func Float32Open(v uint64) float32 {
+	return (float32(v>>41) + 0.5) * 0x1.0p-23
+}
+
+// Float32Closed converts v to a float32 in [0, 1], using the upper 24 bits: the result is a multiple of 1/(2^24-1).
+func Float32Closed(v uint64) float32 {
+	return float32(float64(v>>40) / (1<<24 - 1))
+}
@@ -1,10 +1,12 @@
 package internal
 
+import "encoding/binary"
+
 var (
-	// Jump128 "const" for Jump function
+	// Jump128 "const" for xorshift128+ Jump function
 	Jump128 = []uint64{0x8a5cd789635d2dff, 0x121fd2155c472f96}
 
-	// Jump1024 "const" for Jump function
+	// Jump1024 "const" for xorshift1024* Jump function
 	Jump1024 = []uint64{
 		0x84242f96eca9c41d,
 		0xa3c65b8776f96855, 0x5b34a39f070b5837, 0x4489affce4f31a1e,
@@ -29,14 +31,91 @@ func (x *SplitMix64) Int63() int64 {
 // Uint64 returns the next pseudo random number generated, before start you must provvide one 64 unsigned bit seed.
 func (x *SplitMix64) Uint64() uint64 {
 	x.s = x.s + uint64(0x9E3779B97F4A7C15)
-	z := x.s
+	return mix64(x.s)
+}
+
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *SplitMix64) Prev() uint64 {
+	r := mix64(x.s)
+	x.s = x.s - uint64(0x9E3779B97F4A7C15)
+	return r
+}
+
+// mix64 is the output function of SplitMix64.
+func mix64(z uint64) uint64 {
 	z = (z ^ (z >> 30)) * uint64(0xBF58476D1CE4E5B9)
 	z = (z ^ (z >> 27)) * uint64(0x94D049BB133111EB)
 	return z ^ (z >> 31)
+}
 
+// State returns the internal state of SplitMix64.
+func (x *SplitMix64) State() uint64 {
+	return x.s
 }
 
 // Seed seed SplitMix64 random number generator with the given value.
 func (x *SplitMix64) Seed(seed int64) {
 	x.s = uint64(seed)
 }
+
+// UnshiftRight inverts y = x ^ (x >> k), for 0 < k < 64, returning x.
+func UnshiftRight(y uint64, k uint) uint64 {
+	x := y
+	for s := k; s < 64; s += k {
+		x ^= y >> s
+	}
+	return x
+}
+
+// UnshiftLeft inverts y = x ^ (x << k), for 0 < k < 64, returning x.
+func UnshiftLeft(y uint64, k uint) uint64 {
+	x := y
+	for s := k; s < 64; s += k {
+		x ^= y << s
+	}
+	return x
+}
+
+// ExpandKey fills the state s with values derived from every bit of key, so keys longer than
+// 64 bits aren't truncated. The expansion is stable, the same key gives always the same state:
+//  1. s is filled by a SplitMix64 seeded with the length of the key in bytes;
+//  2. the key is read as little-endian 64-bit words, the last one padded with zeros, and the
+//     i-th word is absorbed into s[i mod len(s)] as s[j] = mix64(s[j] ^ word);
+//  3. if s has more than one word, two chained passes, s[j] = mix64(s[j] ^ h); h = s[j], spread
+//     every word of the key over the whole state.
+//
+// Every step is invertible, so distinct keys of the same length up to 8*len(s) bytes give distinct
+// states. The resulting state is never everywhere zero.
+func ExpandKey(key []byte, s []uint64) {
+	sm := SplitMix64{s: uint64(len(key))}
+	for j := range s {
+		s[j] = sm.Uint64()
+	}
+
+	for i := 0; i*8 < len(key); i++ {
+		var buf [8]byte
+		copy(buf[:], key[i*8:])
+		w := binary.LittleEndian.Uint64(buf[:])
+		j := i % len(s)
+		s[j] = mix64(s[j] ^ w)
+	}
+
+	var h uint64
+	for r := 0; r < 2 && len(s) > 1; r++ {
+		for j := range s {
+			s[j] = mix64(s[j] ^ h)
+			h = s[j]
+		}
+	}
+
+	AvoidZero(s)
+}
+
+// AvoidZero replaces an everywhere zero state, that would make a xorshift generator stuck at zero forever,
+// with a fixed nonzero one. A state obtained hashing a seed is zero with a negligible probability,
+// but this way the seeding functions never produce an invalid generator.
+func AvoidZero(s []uint64) {
+	if IsZero(s) {
+		s[0] = 0x9E3779B97F4A7C15
+	}
+}
@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// Poly is a polynomial over GF(2): bit i of the word i/64 is the coefficient of x^i.
+// It's the same representation used by the jump "const" of the generators.
+type Poly []uint64
+
+// Degree returns the degree of p, -1 for the zero polynomial.
+func (p Poly) Degree() int {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i] != 0 {
+			return i*64 + 63 - bits.LeadingZeros64(p[i])
+		}
+	}
+	return -1
+}
+
+// xorShifted computes dst ^= src * x^n, the bits exceeding dst are dropped.
+func xorShifted(dst, src []uint64, n int) {
+	w, s := n>>6, uint(n&63)
+	for i := 0; i < len(src) && i+w < len(dst); i++ {
+		dst[i+w] ^= src[i] << s
+		if s != 0 && i+w+1 < len(dst) {
+			dst[i+w+1] ^= src[i] >> (64 - s)
+		}
+	}
+}
+
+// CharPoly returns the characteristic polynomial of a linear generator with a degree bits state.
+// next must advance the generator by one step and return, in the lower bit, a fixed bit of its state:
+// the polynomial is computed with the Berlekamp-Massey algorithm from 2*degree consecutive values.
+// For the full period generators of this module, the result doesn't depend on the state
+// the generator starts from, provided it is not everywhere zero.
+func CharPoly(degree int, next func() uint64) Poly {
+	n := 2 * degree
+	size := n/64 + 2
+
+	c := make([]uint64, size) // connection polynomial
+	b := make([]uint64, size) // last connection polynomial before the length change
+	t := make([]uint64, size)
+	w := make([]uint64, size) // bit i is the i-th last value of the sequence
+	c[0], b[0] = 1, 1
+	l, m := 0, 1
+
+	for k := 0; k < n; k++ {
+		for i := size - 1; i > 0; i-- {
+			w[i] = w[i]<<1 | w[i-1]>>63
+		}
+		w[0] = w[0]<<1 | next()&1
+
+		var d uint64
+		for i := 0; i <= l>>6; i++ {
+			d ^= c[i] & w[i]
+		}
+		if bits.OnesCount64(d)&1 == 0 {
+			m++
+			continue
+		}
+		if 2*l <= k {
+			copy(t, c)
+			xorShifted(c, b, m)
+			l = k + 1 - l
+			copy(b, t)
+			m = 1
+		} else {
+			xorShifted(c, b, m)
+			m++
+		}
+	}
+
+	if l != degree {
+		panic("internal: the generator is not full period")
+	}
+
+	// the characteristic polynomial is the reciprocal of the connection polynomial
+	p := make(Poly, degree/64+1)
+	for i := 0; i <= l; i++ {
+		if c[i>>6]>>uint(i&63)&1 != 0 {
+			j := l - i
+			p[j>>6] |= 1 << uint(j&63)
+		}
+	}
+	return p
+}
+
+// reduce returns r mod p, r is modified.
+func reduce(r, p Poly) Poly {
+	d := p.Degree()
+	for i := r.Degree(); i >= d; i-- {
+		if r[i>>6]>>uint(i&63)&1 != 0 {
+			xorShifted(r, p, i-d)
+		}
+	}
+	out := make(Poly, (d+63)/64)
+	copy(out, r)
+	return out
+}
+
+// MulMod returns a*b mod p, a and b must have degree less than the degree of p.
+func MulMod(a, b, p Poly) Poly {
+	r := make(Poly, len(a)+len(b)+1)
+	for i := 0; i < len(a); i++ {
+		for w := a[i]; w != 0; w &= w - 1 {
+			xorShifted(r, b, i*64+bits.TrailingZeros64(w))
+		}
+	}
+	return reduce(r, p)
+}
+
+// sqrMod returns a^2 mod p. Over GF(2) squaring just spreads the coefficients.
+func sqrMod(a, p Poly) Poly {
+	r := make(Poly, 2*len(a)+1)
+	for i, w := range a {
+		r[2*i] = spread(uint32(w))
+		r[2*i+1] = spread(uint32(w >> 32))
+	}
+	return reduce(r, p)
+}
+
+// spread interleaves the bits of v with zeros.
+func spread(v uint32) uint64 {
+	x := uint64(v)
+	x = (x | x<<16) & 0x0000ffff0000ffff
+	x = (x | x<<8) & 0x00ff00ff00ff00ff
+	x = (x | x<<4) & 0x0f0f0f0f0f0f0f0f
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// PowMod returns a^e mod p, a must have degree less than the degree of p.
+func PowMod(a Poly, e *big.Int, p Poly) Poly {
+	r := make(Poly, (p.Degree()+63)/64)
+	r[0] = 1
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = sqrMod(r, p)
+		if e.Bit(i) != 0 {
+			r = MulMod(r, a, p)
+		}
+	}
+	return r
+}
+
+// XPowMod returns x^e mod p. It's like PowMod, but the multiplications by x are just shifts.
+func XPowMod(e *big.Int, p Poly) Poly {
+	d := p.Degree()
+	r := make(Poly, (d+63)/64)
+	r[0] = 1
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = sqrMod(r, p)
+		if e.Bit(i) != 0 {
+			// r = r*x mod p
+			out := r[(d-1)>>6]>>uint((d-1)&63)&1 != 0
+			for j := len(r) - 1; j > 0; j-- {
+				r[j] = r[j]<<1 | r[j-1]>>63
+			}
+			r[0] <<= 1
+			if out {
+				xorShifted(r, p, 0)
+			}
+			if d&63 != 0 {
+				r[len(r)-1] &= 1<<uint(d&63) - 1
+			}
+		}
+	}
+	return r
+}
+
+// Advancer computes the jump polynomials used to move a linear generator ahead of an
+// arbitrary number of steps. The characteristic polynomial of the generator is computed
+// only once, the first time it's needed.
+type Advancer struct {
+	once     sync.Once
+	degree   int
+	sequence func() func() uint64
+	p        Poly
+}
+
+// NewAdvancer return a new Advancer for a generator with a degree bits state.
+// sequence must return a function suitable for CharPoly.
+func NewAdvancer(degree int, sequence func() func() uint64) *Advancer {
+	return &Advancer{degree: degree, sequence: sequence}
+}
+
+// CharPoly returns the characteristic polynomial of the generator.
+func (a *Advancer) CharPoly() Poly {
+	a.once.Do(func() {
+		a.p = CharPoly(a.degree, a.sequence())
+	})
+	return a.p
+}
+
+// JumpPoly returns the jump polynomial equivalent to n steps of the generator.
+// n is taken modulo the period of the generator, 2^degree - 1, so a negative n moves the generator backward.
+func (a *Advancer) JumpPoly(n *big.Int) Poly {
+	p := a.CharPoly()
+	period := new(big.Int).Lsh(big.NewInt(1), uint(a.degree))
+	period.Sub(period, big.NewInt(1))
+
+	if n.Sign() >= 0 {
+		return XPowMod(new(big.Int).Mod(n, period), p)
+	}
+
+	// x^-n = (x^-1)^n, and x^-1 = (p - 1)/x because the constant term of p is 1
+	xinv := make(Poly, (a.degree+63)/64)
+	for i := range xinv {
+		xinv[i] = p[i] >> 1
+		if i+1 < len(p) {
+			xinv[i] |= p[i+1] << 63
+		}
+	}
+	return PowMod(xinv, new(big.Int).Mod(new(big.Int).Neg(n), period), p)
+}
+
+// SequentialJumps is the number of jumps below which n calls to Jump() are faster than
+// computing the n-th power of the jump polynomial with PowPoly.
+const SequentialJumps = 16
+
+// PowPoly returns jump^n mod the characteristic polynomial, the jump polynomial equivalent to n jumps
+// of the given jump polynomial. It takes O(log n) multiplications, independent of the jump distance.
+func (a *Advancer) PowPoly(jump Poly, n uint64) Poly {
+	return PowMod(jump, new(big.Int).SetUint64(n), a.CharPoly())
+}
@@ -0,0 +1,93 @@
+package xorshift
+
+import (
+	"testing"
+
+	"github.com/vpxyz/xorshift/splitmix64"
+	"github.com/vpxyz/xorshift/xoroshiro128plus"
+	"github.com/vpxyz/xorshift/xoroshiro128starstar"
+	"github.com/vpxyz/xorshift/xoroshiro256plus"
+	"github.com/vpxyz/xorshift/xoroshiro256plusplus"
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+	"github.com/vpxyz/xorshift/xoroshiro512plus"
+	"github.com/vpxyz/xorshift/xoroshiro512starstar"
+	"github.com/vpxyz/xorshift/xorshift1024star"
+	"github.com/vpxyz/xorshift/xorshift1024starphi"
+	"github.com/vpxyz/xorshift/xorshift128plus"
+	"github.com/vpxyz/xorshift/xorshift4096star"
+	"github.com/vpxyz/xorshift/xorshift64star"
+)
+
+// keySource pairs a generator name with its NewSourceFromKey function and its state size in bytes.
+type keySource struct {
+	name  string
+	size  int
+	fromK func(key []byte) XorShift
+}
+
+var keySources = []keySource{
+	{"splitmix64", 8, func(k []byte) XorShift { return splitmix64.NewSourceFromKey(k) }},
+	{"xorshift64*", 8, func(k []byte) XorShift { return xorshift64star.NewSourceFromKey(k) }},
+	{"xorshift128+", 16, func(k []byte) XorShift { return xorshift128plus.NewSourceFromKey(k) }},
+	{"xoroshiro128+", 16, func(k []byte) XorShift { return xoroshiro128plus.NewSourceFromKey(k) }},
+	{"xoroshiro128+ (2016)", 16, func(k []byte) XorShift { return xoroshiro128plus.NewLegacySourceFromKey(k) }},
+	{"xoroshiro128**", 16, func(k []byte) XorShift { return xoroshiro128starstar.NewSourceFromKey(k) }},
+	{"xoroshiro256+", 32, func(k []byte) XorShift { return xoroshiro256plus.NewSourceFromKey(k) }},
+	{"xoroshiro256++", 32, func(k []byte) XorShift { return xoroshiro256plusplus.NewSourceFromKey(k) }},
+	{"xoroshiro256**", 32, func(k []byte) XorShift { return xoroshiro256starstar.NewSourceFromKey(k) }},
+	{"xoroshiro512+", 64, func(k []byte) XorShift { return xoroshiro512plus.NewSourceFromKey(k) }},
+	{"xoroshiro512**", 64, func(k []byte) XorShift { return xoroshiro512starstar.NewSourceFromKey(k) }},
+	{"xorshift1024*", 128, func(k []byte) XorShift { return xorshift1024star.NewSourceFromKey(k) }},
+	{"xorshift1024*phi", 128, func(k []byte) XorShift { return xorshift1024starphi.NewSourceFromKey(k) }},
+	{"xorshift4096*", 512, func(k []byte) XorShift { return xorshift4096star.NewSourceFromKey(k) }},
+}
+
+func TestSeedBytes(t *testing.T) {
+	for _, ks := range keySources {
+		key := []byte("user-42")
+		if !sameOutput(ks.fromK(key), ks.fromK(key), 16) {
+			t.Errorf("%s: the same key gives different outputs", ks.name)
+		}
+
+		// SeedBytes on a used generator must give the same state as NewSourceFromKey
+		x := ks.fromK([]byte("other"))
+		x.Uint64()
+		x.(interface{ SeedBytes([]byte) }).SeedBytes(key)
+		if !sameOutput(x, ks.fromK(key), 16) {
+			t.Errorf("%s: SeedBytes differs from NewSourceFromKey", ks.name)
+		}
+
+		for _, other := range [][]byte{nil, []byte("user-43"), []byte("user-42\x00"), []byte("user-4")} {
+			if sameOutput(ks.fromK(key), ks.fromK(other), 4) {
+				t.Errorf("%s: keys %q and %q give the same output", ks.name, key, other)
+			}
+		}
+
+		// flipping the last bit of a key as long as the state must change the output
+		long := make([]byte, ks.size)
+		for i := range long {
+			long[i] = byte(i)
+		}
+		flipped := append([]byte(nil), long...)
+		flipped[len(flipped)-1] ^= 0x80
+		if sameOutput(ks.fromK(long), ks.fromK(flipped), 4) {
+			t.Errorf("%s: the last bit of a %d bytes key is ignored", ks.name, ks.size)
+		}
+	}
+}
+
+func TestSeedBytesStable(t *testing.T) {
+	// the key-to-state expansion must not change across releases
+	x := xoroshiro256starstar.NewSourceFromKey([]byte("xorshift"))
+	y := xorshift1024star.NewSourceFromKey([]byte("xorshift"))
+	wantX := []uint64{0xca514549287925e4, 0x0fdc6d54b333cf7a, 0x7fd5c5983eb9d3bd}
+	wantY := []uint64{0x6e46049c8e8c6de0, 0x740a042a635182a6, 0x622b041a066fc931}
+	for i := range wantX {
+		if v := x.Uint64(); v != wantX[i] {
+			t.Errorf("xoroshiro256**: value %d is %#x, expected %#x", i, v, wantX[i])
+		}
+		if v := y.Uint64(); v != wantY[i] {
+			t.Errorf("xorshift1024*: value %d is %#x, expected %#x", i, v, wantY[i])
+		}
+	}
+}
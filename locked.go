@@ -0,0 +1,47 @@
+package xorshift
+
+import "sync"
+
+// LockedSource wraps a generator with a mutex, so it can be used concurrently by many goroutines.
+// It implements XorShiftExt and rand.Source64, e.g. rand.New(NewLockedSource(x)) is concurrency-safe.
+type LockedSource struct {
+	mu sync.Mutex
+	x  XorShift
+}
+
+// NewLockedSource return a new LockedSource that wraps x.
+// x must not be used directly anymore, or the lock is useless.
+func NewLockedSource(x XorShift) *LockedSource {
+	return &LockedSource{x: x}
+}
+
+// Seed use the provvided seed value to init the internal state of the wrapped generator.
+func (l *LockedSource) Seed(seed int64) {
+	l.mu.Lock()
+	l.x.Seed(seed)
+	l.mu.Unlock()
+}
+
+// Uint64 returns the next pseudo random number generated by the wrapped generator.
+func (l *LockedSource) Uint64() uint64 {
+	l.mu.Lock()
+	v := l.x.Uint64()
+	l.mu.Unlock()
+	return v
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+func (l *LockedSource) Int63() int64 {
+	return int64(l.Uint64() & (1<<63 - 1))
+}
+
+// Jump calls Jump() of the wrapped generator. It panics if the generator doesn't implement XorShiftExt (e.g. splitmix64).
+func (l *LockedSource) Jump() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	x, ok := l.x.(XorShiftExt)
+	if !ok {
+		panic(ErrNoJump)
+	}
+	x.Jump()
+}
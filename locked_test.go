@@ -0,0 +1,73 @@
+package xorshift
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/vpxyz/xorshift/splitmix64"
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+)
+
+// run it with go test -race, to check there is no data race.
+func TestLockedSource(t *testing.T) {
+	const goroutines, n = 8, 2000
+
+	l := NewLockedSource(xoroshiro256starstar.NewSource(1))
+	values := make([][]uint64, goroutines)
+	var wg sync.WaitGroup
+	for g := range values {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			r := rand.New(l)
+			for i := 0; i < n; i++ {
+				if i%2 == 0 {
+					values[g] = append(values[g], l.Uint64())
+				} else {
+					values[g] = append(values[g], uint64(r.Int63()))
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// every value of the sequence must be handed out exactly once (Int63 drops the upper bit)
+	var got, want []uint64
+	for _, v := range values {
+		for _, u := range v {
+			got = append(got, u&(1<<63-1))
+		}
+	}
+	x := xoroshiro256starstar.NewSource(1)
+	for i := 0; i < goroutines*n; i++ {
+		want = append(want, x.Uint64()&(1<<63-1))
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+	sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("the values generated concurrently differ from the sequential ones")
+		}
+	}
+}
+
+func TestLockedSourceJump(t *testing.T) {
+	l := NewLockedSource(xoroshiro256starstar.NewSource(1))
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				l.Jump()
+				l.Seed(int64(i))
+				l.Uint64()
+			}
+		}()
+	}
+	wg.Wait()
+
+	expectPanic(t, "Jump() of a LockedSource wrapping splitmix64", func() { NewLockedSource(splitmix64.NewSource(1)).Jump() })
+}
@@ -0,0 +1,160 @@
+package xorshift
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// The errors returned by a Monitor.
+var (
+	// ErrStopped the monitor goroutine was stopped, or its context canceled.
+	ErrStopped = errors.New("xorshift: the monitor is stopped")
+
+	// ErrNoJump the generator doesn't implement XorShiftExt (e.g. splitmix64).
+	ErrNoJump = errors.New("xorshift: the generator has no Jump function")
+)
+
+// command is a request to the monitor goroutine, the result is sent on reply.
+type command struct {
+	apply func(x XorShift) error
+	reply chan error
+}
+
+// Monitor owns a generator in a goroutine, and serves the generated values over a channel, in batches.
+// The Monitor can be used concurrently by many goroutines: each batch is received by only one of them.
+// Seed and Jump are sent to the goroutine on a channel too, so they're serialized with the generation.
+// The batches already generated (and buffered) when a command arrives are discarded, so every batch
+// received after Seed or Jump returns is generated by the new state. If the generator implements
+// XorShiftReverser (all the sub packages do) it's also moved back over the discarded values,
+// so that Jump is applied right after the last value received, and the results are reproducible.
+type Monitor struct {
+	out  chan []uint64
+	cmds chan command
+	done chan struct{}
+
+	cancel context.CancelFunc
+	once   sync.Once
+}
+
+// NewMonitor starts a goroutine that owns x, and return the Monitor to talk to it. The goroutine
+// generates batches of size values (at least 1), and keeps buffer batches (at least 0) ready to be received.
+// The goroutine runs until Stop is called or ctx is canceled. x must not be used directly anymore.
+func NewMonitor(ctx context.Context, x XorShift, size, buffer int) *Monitor {
+	if size < 1 {
+		size = 1
+	}
+	if buffer < 0 {
+		buffer = 0
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	m := &Monitor{
+		out:    make(chan []uint64, buffer),
+		cmds:   make(chan command),
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+	go m.run(ctx, x, size)
+	return m
+}
+
+// run is the monitor goroutine.
+func (m *Monitor) run(ctx context.Context, x XorShift, size int) {
+	defer close(m.done)
+	defer close(m.out)
+
+	fill := func() []uint64 {
+		b := make([]uint64, size)
+		for i := range b {
+			b[i] = x.Uint64()
+		}
+		return b
+	}
+
+	batch := fill()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case c := <-m.cmds:
+			if r, ok := x.(XorShiftReverser); ok {
+				for n := m.drain()*size + len(batch); n > 0; n-- {
+					r.Prev()
+				}
+			} else {
+				m.drain()
+			}
+			c.reply <- c.apply(x)
+			batch = fill()
+		case m.out <- batch:
+			batch = fill()
+		}
+	}
+}
+
+// drain discards the buffered batches, and returns how many they were.
+// Only the monitor goroutine sends on out, so it ends.
+func (m *Monitor) drain() int {
+	n := 0
+	for {
+		select {
+		case <-m.out:
+			n++
+		default:
+			return n
+		}
+	}
+}
+
+// Values returns the channel of the generated batches. It's closed when the monitor stops,
+// but the batches already buffered can still be received.
+func (m *Monitor) Values() <-chan []uint64 {
+	return m.out
+}
+
+// Batch returns the next batch of values. It returns ErrStopped when the monitor is stopped
+// and there are no more buffered batches.
+func (m *Monitor) Batch() ([]uint64, error) {
+	b, ok := <-m.out
+	if !ok {
+		return nil, ErrStopped
+	}
+	return b, nil
+}
+
+// do sends c to the monitor goroutine, and waits for its result.
+func (m *Monitor) do(c command) error {
+	c.reply = make(chan error, 1)
+	select {
+	case m.cmds <- c:
+		return <-c.reply
+	case <-m.done:
+		return ErrStopped
+	}
+}
+
+// Seed use the provvided seed value to init the internal state of the generator.
+func (m *Monitor) Seed(seed int64) error {
+	return m.do(command{apply: func(x XorShift) error {
+		x.Seed(seed)
+		return nil
+	}})
+}
+
+// Jump calls Jump() of the generator. It returns ErrNoJump if the generator doesn't implement XorShiftExt.
+func (m *Monitor) Jump() error {
+	return m.do(command{apply: func(x XorShift) error {
+		j, ok := x.(XorShiftExt)
+		if !ok {
+			return ErrNoJump
+		}
+		j.Jump()
+		return nil
+	}})
+}
+
+// Stop stops the monitor goroutine, and waits for its end. It can be called more than once.
+func (m *Monitor) Stop() {
+	m.once.Do(m.cancel)
+	<-m.done
+}
@@ -0,0 +1,106 @@
+package xorshift
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vpxyz/xorshift/splitmix64"
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+)
+
+func TestMonitor(t *testing.T) {
+	m := NewMonitor(context.Background(), xoroshiro256starstar.NewSource(1), 16, 4)
+	defer m.Stop()
+
+	x := xoroshiro256starstar.NewSource(1)
+	for i := 0; i < 10; i++ {
+		b, err := m.Batch()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b) != 16 {
+			t.Fatalf("batch of %d values, expected 16", len(b))
+		}
+		for _, v := range b {
+			if w := x.Uint64(); v != w {
+				t.Fatalf("the monitor generated %#x, expected %#x", v, w)
+			}
+		}
+	}
+
+	// the batches buffered before Seed and Jump are discarded, and
+	// the generator moved back, so Jump is applied right after the last value received
+	<-m.Values()
+	if err := m.Seed(42); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Jump(); err != nil {
+		t.Fatal(err)
+	}
+	x = xoroshiro256starstar.NewSource(42)
+	x.Jump()
+	b := <-m.Values()
+	for _, v := range b {
+		if w := x.Uint64(); v != w {
+			t.Fatalf("after Seed and Jump the monitor generated %#x, expected %#x", v, w)
+		}
+	}
+}
+
+func TestMonitorConcurrent(t *testing.T) {
+	m := NewMonitor(context.Background(), xoroshiro256starstar.NewSource(1), 8, 2)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if _, err := m.Batch(); err != nil {
+					t.Error(err)
+					return
+				}
+				if i%10 == 0 {
+					if err := m.Seed(int64(g)); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	m.Stop()
+	m.Stop()
+
+	for range m.Values() { // the buffered batches
+	}
+	if _, err := m.Batch(); err != ErrStopped {
+		t.Errorf("Batch() after Stop: %v, expected ErrStopped", err)
+	}
+	if err := m.Jump(); err != ErrStopped {
+		t.Errorf("Jump() after Stop: %v, expected ErrStopped", err)
+	}
+}
+
+func TestMonitorContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := NewMonitor(ctx, splitmix64.NewSource(1), 1, 0)
+	if err := m.Jump(); err != ErrNoJump {
+		t.Errorf("Jump() of splitmix64: %v, expected ErrNoJump", err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-m.Values():
+		for ok {
+			_, ok = <-m.Values()
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the monitor goroutine doesn't stop when the context is canceled")
+	}
+	if err := m.Seed(1); err != ErrStopped {
+		t.Errorf("Seed() after cancel: %v, expected ErrStopped", err)
+	}
+}
@@ -0,0 +1,36 @@
+package xorshift
+
+import "testing"
+
+func TestPrev(t *testing.T) {
+	const n = 200
+
+	for _, c := range conformanceCases {
+		x, ok := c.load(c.state).(XorShiftReverser)
+		if !ok {
+			t.Errorf("%s: does not implement XorShiftReverser", c.name)
+			continue
+		}
+
+		values := make([]uint64, n)
+		for i := range values {
+			values[i] = x.Uint64()
+		}
+		for i := n - 1; i >= 0; i-- {
+			if got := x.Prev(); got != values[i] {
+				t.Errorf("%s: Prev() = %#016x, want %#016x", c.name, got, values[i])
+				break
+			}
+		}
+		if !sameOutput(x, c.load(c.state), 8) {
+			t.Errorf("%s: Prev() doesn't restore the initial state", c.name)
+		}
+
+		// walk before the initial state and come back
+		x = c.load(c.state).(XorShiftReverser)
+		v := x.Prev()
+		if got := x.Uint64(); got != v {
+			t.Errorf("%s: Uint64() after Prev() = %#016x, want %#016x", c.name, got, v)
+		}
+	}
+}
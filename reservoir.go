@@ -0,0 +1,154 @@
+package xorshift
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/vpxyz/xorshift/internal"
+)
+
+// Reservoir keeps a uniform sample without replacement of k items from a stream of unknown length,
+// with Li's Algorithm L ("Reservoir-sampling algorithms of time complexity O(n(1+log(N/n)))", 1994),
+// an improvement of Vitter's algorithms: the number of items to skip before the next replacement has a
+// geometric-like distribution, so the generator is used only O(k log(n/k)) times for n items.
+// Not concurrency-safe.
+type Reservoir[T any] struct {
+	x     XorShift
+	items []T
+	seen  uint64
+	next  uint64  // index of the next item that enters the sample
+	w     float64 // the largest of k uniform values
+}
+
+// NewReservoir return a new Reservoir that keeps k items, using the generator x. It panics if k <= 0.
+func NewReservoir[T any](k int, x XorShift) *Reservoir[T] {
+	if k <= 0 {
+		panic("xorshift: invalid argument to NewReservoir")
+	}
+	return &Reservoir[T]{x: x, items: make([]T, 0, k)}
+}
+
+// Add offers the next item of the stream to the sample.
+func (r *Reservoir[T]) Add(item T) {
+	i := r.seen
+	r.seen++
+	k := cap(r.items)
+	if len(r.items) < k {
+		r.items = append(r.items, item)
+		if len(r.items) == k {
+			r.w = math.Exp(math.Log(internal.Float64Open(r.x.Uint64())) / float64(k))
+			r.next = uint64(k) + r.skip()
+		}
+		return
+	}
+	if i == r.next {
+		r.items[Uint64n(r.x, uint64(k))] = item
+		r.w *= math.Exp(math.Log(internal.Float64Open(r.x.Uint64())) / float64(k))
+		r.next += r.skip() + 1
+	}
+}
+
+// skip returns the number of items to skip before the next replacement.
+func (r *Reservoir[T]) skip() uint64 {
+	s := math.Floor(math.Log(internal.Float64Open(r.x.Uint64())) / math.Log1p(-r.w))
+	if !(s < 1<<62) { // w is so small that the reservoir is never updated again, in practice
+		return 1 << 62
+	}
+	return uint64(s)
+}
+
+// Sample returns the sampled items, in no particular order: all the items seen, if they're less than k.
+// The slice is owned by the Reservoir, and changes with the next calls to Add.
+func (r *Reservoir[T]) Sample() []T {
+	return r.items
+}
+
+// Seen returns the number of items offered to the sample.
+func (r *Reservoir[T]) Seen() uint64 {
+	return r.seen
+}
+
+// WeightedReservoir keeps a weighted sample without replacement of k items from a stream of unknown length,
+// with the algorithm A-ExpJ of Efraimidis and Spirakis ("Weighted random sampling with a reservoir", 2006):
+// it's equivalent to give every item the key U^(1/weight), and to keep the k items with the largest keys,
+// that is to draw k items one after the other, each one with probability proportional to its weight among the
+// remaining ones. The exponential jumps skip the items that can't enter the sample, so the generator is used
+// only O(k log(n/k)) times for n items. Not concurrency-safe.
+type WeightedReservoir[T any] struct {
+	x    XorShift
+	k    int
+	heap keyHeap[T] // min-heap of the log keys
+	jump float64    // the weight to skip before the next replacement
+}
+
+// NewWeightedReservoir return a new WeightedReservoir that keeps k items, using the generator x. It panics if k <= 0.
+func NewWeightedReservoir[T any](k int, x XorShift) *WeightedReservoir[T] {
+	if k <= 0 {
+		panic("xorshift: invalid argument to NewWeightedReservoir")
+	}
+	return &WeightedReservoir[T]{x: x, k: k, heap: make(keyHeap[T], 0, k)}
+}
+
+// Add offers the next item of the stream to the sample, with the given weight. The items with weight zero
+// never enter the sample. It panics if the weight is negative or not finite.
+func (r *WeightedReservoir[T]) Add(item T, weight float64) {
+	if !(weight >= 0) || math.IsInf(weight, 1) {
+		panic("xorshift: invalid weight for WeightedReservoir")
+	}
+	if weight == 0 {
+		return
+	}
+	if len(r.heap) < r.k {
+		heap.Push(&r.heap, keyed[T]{item, math.Log(internal.Float64Open(r.x.Uint64())) / weight})
+		if len(r.heap) == r.k {
+			r.newJump()
+		}
+		return
+	}
+
+	r.jump -= weight
+	if r.jump > 0 {
+		return
+	}
+	// the key of the item is uniform in (t, 1), t = T^weight with T the smallest key of the sample
+	t := math.Exp(r.heap[0].logKey * weight)
+	u := t + internal.Float64Open(r.x.Uint64())*(1-t)
+	r.heap[0] = keyed[T]{item, math.Log(u) / weight}
+	heap.Fix(&r.heap, 0)
+	r.newJump()
+}
+
+// newJump draws the total weight to skip before the next replacement, log(U)/log(T).
+func (r *WeightedReservoir[T]) newJump() {
+	r.jump = math.Log(internal.Float64Open(r.x.Uint64())) / r.heap[0].logKey
+}
+
+// Sample returns the sampled items, in no particular order: all the items seen with a positive weight,
+// if they're less than k.
+func (r *WeightedReservoir[T]) Sample() []T {
+	s := make([]T, len(r.heap))
+	for i, e := range r.heap {
+		s[i] = e.item
+	}
+	return s
+}
+
+// keyed is an item of a WeightedReservoir, with the logarithm of its key.
+type keyed[T any] struct {
+	item   T
+	logKey float64
+}
+
+// keyHeap is a min-heap of keyed items, for container/heap.
+type keyHeap[T any] []keyed[T]
+
+func (h keyHeap[T]) Len() int            { return len(h) }
+func (h keyHeap[T]) Less(i, j int) bool  { return h[i].logKey < h[j].logKey }
+func (h keyHeap[T]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *keyHeap[T]) Push(v interface{}) { *h = append(*h, v.(keyed[T])) }
+func (h *keyHeap[T]) Pop() interface{} {
+	old := *h
+	v := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return v
+}
@@ -0,0 +1,152 @@
+package xorshift
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/vpxyz/xorshift/xoroshiro256plusplus"
+)
+
+// chiSquaredProbs checks with a chi-squared test the counts of n outcomes against their probabilities.
+func chiSquaredProbs(t *testing.T, name string, n int, counts map[string]int, probs map[string]float64) {
+	t.Helper()
+	var chi2 float64
+	for o, p := range probs {
+		e := float64(n) * p
+		d := float64(counts[o]) - e
+		chi2 += d * d / e
+	}
+	for o := range counts {
+		if _, ok := probs[o]; !ok {
+			t.Errorf("%s: unexpected outcome %s", name, o)
+		}
+	}
+	if crit := chiSquaredCritical(len(probs) - 1); chi2 > crit {
+		t.Errorf("%s: chi-squared = %.1f with %d degrees of freedom, critical value %.1f", name, chi2, len(probs)-1, crit)
+	}
+}
+
+func TestReservoir(t *testing.T) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+
+	// every subset of 2 out of 6 items is equally likely
+	uniformOutcomes(t, "Reservoir 2 of 6", 60000, 15, func() string {
+		r := NewReservoir[int](2, x)
+		for i := 0; i < 6; i++ {
+			r.Add(i)
+		}
+		s := append([]int(nil), r.Sample()...)
+		sort.Ints(s)
+		return fmt.Sprint(s)
+	})
+
+	// in a long stream, where the skips matter, every item has the same probability k/n to be sampled
+	const trials, n, k = 4000, 1000, 10
+	counts := make([]int, n)
+	for i := 0; i < trials; i++ {
+		r := NewReservoir[int](k, x)
+		for j := 0; j < n; j++ {
+			r.Add(j)
+		}
+		if r.Seen() != n || len(r.Sample()) != k {
+			t.Fatalf("Seen() = %d, len(Sample()) = %d", r.Seen(), len(r.Sample()))
+		}
+		for _, v := range r.Sample() {
+			counts[v]++
+		}
+	}
+	// the first and the last 100 items, summed, have an expected count of trials*k*100/n = 4000
+	var first, last int
+	for i := 0; i < 100; i++ {
+		first += counts[i]
+		last += counts[n-1-i]
+	}
+	for _, c := range []int{first, last} {
+		if math.Abs(float64(c)-4000) > 5*math.Sqrt(4000) {
+			t.Errorf("100 items sampled %d times, expected about 4000", c)
+		}
+	}
+
+	// less items than k
+	r := NewReservoir[string](5, x)
+	r.Add("a")
+	r.Add("b")
+	if s := r.Sample(); len(s) != 2 || s[0] != "a" || s[1] != "b" {
+		t.Errorf("Sample() = %v, expected [a b]", s)
+	}
+}
+
+func TestWeightedReservoir(t *testing.T) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	w := []float64{1, 2, 3, 4, 0}
+	var sum float64
+	for _, v := range w {
+		sum += v
+	}
+
+	// with k = 1, the probability of every item is proportional to its weight
+	const n = 50000
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		r := NewWeightedReservoir[int](1, x)
+		for j, v := range w {
+			r.Add(j, v)
+		}
+		counts[fmt.Sprint(r.Sample())]++
+	}
+	probs := map[string]float64{}
+	for j, v := range w[:4] {
+		probs[fmt.Sprint([]int{j})] = v / sum
+	}
+	chiSquaredProbs(t, "WeightedReservoir 1 of 4", n, counts, probs)
+
+	// with k = 2, P({i, j}) = wi/W * wj/(W-wi) + wj/W * wi/(W-wj), repeating the weights
+	// to have more jumps
+	ww := append(append([]float64(nil), w...), w...)
+	counts = map[string]int{}
+	for i := 0; i < n; i++ {
+		r := NewWeightedReservoir[int](2, x)
+		for j, v := range ww {
+			r.Add(j, v)
+		}
+		s := r.Sample()
+		sort.Ints(s)
+		counts[fmt.Sprint(s)]++
+	}
+	probs = map[string]float64{}
+	wsum := 2 * sum
+	for i := range ww {
+		for j := i + 1; j < len(ww); j++ {
+			if ww[i] == 0 || ww[j] == 0 {
+				continue
+			}
+			probs[fmt.Sprint([]int{i, j})] = ww[i]/wsum*ww[j]/(wsum-ww[i]) + ww[j]/wsum*ww[i]/(wsum-ww[j])
+		}
+	}
+	chiSquaredProbs(t, "WeightedReservoir 2 of 8", n, counts, probs)
+
+	expectPanic(t, "Add with weight -1", func() { NewWeightedReservoir[int](1, x).Add(1, -1) })
+	expectPanic(t, "Add with weight NaN", func() { NewWeightedReservoir[int](1, x).Add(1, math.NaN()) })
+	expectPanic(t, "NewWeightedReservoir(0)", func() { NewWeightedReservoir[int](0, x) })
+	expectPanic(t, "NewReservoir(0)", func() { NewReservoir[int](0, x) })
+}
+
+func BenchmarkReservoir(b *testing.B) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	r := NewReservoir[int](100, x)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.Add(i)
+	}
+}
+
+func BenchmarkWeightedReservoir(b *testing.B) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	r := NewWeightedReservoir[int](100, x)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.Add(i, float64(i%10+1))
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Package seedseq implements a seed sequence, to derive the initial state of many independent generators
+from a single experiment seed.
+
+The algorithm is the one of NumPy's SeedSequence (numpy.random.SeedSequence, by Robert Kern,
+based on Melissa O'Neill's seed_seq design): the entropy is hashed into a pool of 128 bits, that is then
+expanded into as many state words as needed. Every SeedSequence can Spawn children, that are
+identified by the path of spawn indexes from the root (the spawn key) and are mixed with the
+same entropy, so a tree of seeds (per worker, per replica, ...) is deterministic and collision resistant,
+unlike hand-rolled seed+i values. With the same entropy and spawn key, GenerateState returns the same
+words of NumPy's generate_state(n, numpy.uint64).
+
+	root := seedseq.New(20180829)
+	for i, child := range root.Spawn(8) {
+		workers[i] = xoroshiro256starstar.NewSourceFromSeedSequence(child)
+	}
+*/
+package seedseq
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+const (
+	poolSize = 4 // pool size in 32-bit words
+
+	initA    = 0x43b0d7e5
+	multA    = 0x931e8875
+	initB    = 0x8b51f9dd
+	multB    = 0x58f38ded
+	mixMultL = 0xca01f9dd
+	mixMultR = 0x4973f715
+	xshift   = 16
+)
+
+// SeedSequence holds the hashed entropy pool and the position in the tree of spawned seeds.
+// Not concurrency-safe: Spawn modifies the number of children spawned.
+type SeedSequence struct {
+	entropy  []uint64
+	spawnKey []uint64
+	spawned  uint64
+	pool     [poolSize]uint32
+}
+
+// New return a new root SeedSequence, mixing all the given entropy values (e.g. an experiment seed,
+// or a 128-bit seed as two words). New() without values is equivalent to New(0).
+func New(entropy ...uint64) *SeedSequence {
+	return newSeedSequence(append([]uint64(nil), entropy...), nil)
+}
+
+// NewRandom return a new root SeedSequence with 128 bits of entropy from crypto/rand.
+// The entropy can be logged with Entropy, to reproduce the run with New.
+func NewRandom() (*SeedSequence, error) {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return nil, err
+	}
+	return New(binary.LittleEndian.Uint64(buf[:]), binary.LittleEndian.Uint64(buf[8:])), nil
+}
+
+func newSeedSequence(entropy, spawnKey []uint64) *SeedSequence {
+	ss := &SeedSequence{entropy: entropy, spawnKey: spawnKey}
+	ss.mixEntropy(ss.assembledEntropy())
+	return ss
+}
+
+// Entropy returns the entropy values of the root SeedSequence.
+func (ss *SeedSequence) Entropy() []uint64 {
+	return append([]uint64(nil), ss.entropy...)
+}
+
+// SpawnKey returns the spawn indexes that lead from the root to ss, nil for the root.
+func (ss *SeedSequence) SpawnKey() []uint64 {
+	return append([]uint64(nil), ss.spawnKey...)
+}
+
+// Spawned returns the number of children spawned so far.
+func (ss *SeedSequence) Spawned() uint64 {
+	return ss.spawned
+}
+
+// Spawn returns n new children of ss. Calling Spawn again returns new children, different from the previous ones:
+// the i-th child ever spawned has the spawn key of ss followed by i.
+func (ss *SeedSequence) Spawn(n int) []*SeedSequence {
+	children := make([]*SeedSequence, n)
+	for i := range children {
+		key := make([]uint64, len(ss.spawnKey)+1)
+		copy(key, ss.spawnKey)
+		key[len(ss.spawnKey)] = ss.spawned
+		ss.spawned++
+		children[i] = newSeedSequence(ss.entropy, key)
+	}
+	return children
+}
+
+// GenerateState returns n 64-bit words derived from the entropy pool, suitable to fill the state of a generator.
+// It doesn't modify ss: the same SeedSequence returns always the same words, and the first words
+// don't depend on n.
+func (ss *SeedSequence) GenerateState(n int) []uint64 {
+	s := make([]uint64, n)
+	ss.Fill(s)
+	return s
+}
+
+// Fill is like GenerateState, but writes len(s) words into s.
+func (ss *SeedSequence) Fill(s []uint64) {
+	h := uint32(initB)
+	next := func(i int) uint32 {
+		v := ss.pool[i%poolSize] ^ h
+		h *= multB
+		v *= h
+		return v ^ v>>xshift
+	}
+	for i := range s {
+		lo := next(2 * i)
+		s[i] = uint64(lo) | uint64(next(2*i+1))<<32
+	}
+}
+
+// assembledEntropy returns the entropy followed by the spawn key, as 32-bit words.
+// If there is a spawn key, a short entropy is padded with zeros to the pool size, so that a child
+// is different from a root with the spawn key appended to its entropy (as NumPy does since 1.19).
+func (ss *SeedSequence) assembledEntropy() []uint32 {
+	run := toWords(ss.entropy)
+	if len(ss.spawnKey) > 0 {
+		for len(run) < poolSize {
+			run = append(run, 0)
+		}
+	}
+	return append(run, toWords(ss.spawnKey)...)
+}
+
+// toWords splits every value into 32-bit words, least significant first,
+// with no leading zero words, except for 0 that is a single zero word.
+func toWords(v []uint64) []uint32 {
+	if len(v) == 0 {
+		return []uint32{}
+	}
+	w := make([]uint32, 0, 2*len(v))
+	for _, x := range v {
+		w = append(w, uint32(x))
+		if x>>32 != 0 {
+			w = append(w, uint32(x>>32))
+		}
+	}
+	return w
+}
+
+// hashMix hashes v with the running constant h.
+func hashMix(v uint32, h *uint32) uint32 {
+	v ^= *h
+	*h *= multA
+	v *= *h
+	return v ^ v>>xshift
+}
+
+// mix combines two hashed values.
+func mix(x, y uint32) uint32 {
+	r := mixMultL*x - mixMultR*y
+	return r ^ r>>xshift
+}
+
+// mixEntropy hashes the entropy words into the pool.
+func (ss *SeedSequence) mixEntropy(e []uint32) {
+	h := uint32(initA)
+	p := &ss.pool
+	for i := range p {
+		var v uint32
+		if i < len(e) {
+			v = e[i]
+		}
+		p[i] = hashMix(v, &h)
+	}
+	for src := range p {
+		for dst := range p {
+			if src != dst {
+				p[dst] = mix(p[dst], hashMix(p[src], &h))
+			}
+		}
+	}
+	for src := poolSize; src < len(e); src++ {
+		for dst := range p {
+			p[dst] = mix(p[dst], hashMix(e[src], &h))
+		}
+	}
+}
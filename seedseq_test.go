@@ -0,0 +1,87 @@
+package xorshift
+
+import (
+	"testing"
+
+	"github.com/vpxyz/xorshift/seedseq"
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+	"github.com/vpxyz/xorshift/xorshift4096star"
+)
+
+func TestSeedSequenceReference(t *testing.T) {
+	// from the reference data of NumPy's SeedSequence, generate_state(4, numpy.uint32)
+	ss := seedseq.New(3735928559, 195939070, 229505742, 305419896)
+	want := []uint32{3914649087, 576849849, 3593928901, 2229911004}
+	s := ss.GenerateState(2)
+	got := []uint32{uint32(s[0]), uint32(s[0] >> 32), uint32(s[1]), uint32(s[1] >> 32)}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("GenerateState = %v, expected %v", got, want)
+		}
+	}
+
+	if s := ss.GenerateState(5); s[0] != ss.GenerateState(1)[0] {
+		t.Errorf("the first word of GenerateState depends on the number of words")
+	}
+	if a, b := seedseq.New().GenerateState(2), seedseq.New(0).GenerateState(2); a[0] != b[0] || a[1] != b[1] {
+		t.Errorf("New() differs from New(0)")
+	}
+}
+
+func TestSeedSequenceSpawn(t *testing.T) {
+	root := seedseq.New(42)
+	children := root.Spawn(100)
+	more := root.Spawn(100)
+	if root.Spawned() != 200 {
+		t.Errorf("Spawned = %d, expected 200", root.Spawned())
+	}
+
+	seen := map[uint64]bool{root.GenerateState(1)[0]: true}
+	for _, c := range append(children, more...) {
+		seen[c.GenerateState(1)[0]] = true
+	}
+	grandchildren := children[0].Spawn(100)
+	for _, c := range grandchildren {
+		seen[c.GenerateState(1)[0]] = true
+	}
+	if len(seen) != 301 {
+		t.Errorf("%d different first words out of 301 seed sequences", len(seen))
+	}
+
+	// spawning is deterministic
+	again := seedseq.New(42).Spawn(101)
+	if again[100].GenerateState(1)[0] != more[0].GenerateState(1)[0] {
+		t.Errorf("the 101st child differs between two spawns of the same root")
+	}
+	if k := grandchildren[7].SpawnKey(); len(k) != 2 || k[0] != 0 || k[1] != 7 {
+		t.Errorf("SpawnKey = %v, expected [0 7]", k)
+	}
+	if e := grandchildren[7].Entropy(); len(e) != 1 || e[0] != 42 {
+		t.Errorf("Entropy = %v, expected [42]", e)
+	}
+
+	// the 2nd child of the root with entropy 42 must differ from a root with entropy 42 and 1
+	if seedseq.New(42, 1).GenerateState(1)[0] == children[1].GenerateState(1)[0] {
+		t.Errorf("a spawned child collides with the root with the spawn key appended to its entropy")
+	}
+}
+
+func TestSeedFrom(t *testing.T) {
+	ss := seedseq.New(7)
+	s := ss.GenerateState(4)
+	x := xoroshiro256starstar.NewSourceFromSeedSequence(ss)
+	y, _ := xoroshiro256starstar.NewSourceFromState([4]uint64{s[0], s[1], s[2], s[3]})
+	if !sameOutput(x, y, 8) {
+		t.Errorf("xoroshiro256**: SeedFrom differs from the state generated by the seed sequence")
+	}
+
+	var s64 [64]uint64
+	ss.Fill(s64[:])
+	z := xorshift4096star.NewSource(1)
+	z.Uint64()
+	z.SeedFrom(ss)
+	w, _ := xorshift4096star.NewSourceFromState(s64)
+	if !sameOutput(z, w, 8) {
+		t.Errorf("xorshift4096*: SeedFrom differs from the state generated by the seed sequence")
+	}
+}
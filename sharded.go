@@ -0,0 +1,62 @@
+package xorshift
+
+import (
+	"sync"
+
+	"github.com/vpxyz/xorshift/internal"
+)
+
+// ShardedSource is a pool of generators that can be used concurrently by many goroutines without
+// a global lock: every P (the runtime processor running the goroutine) takes a generator from its own
+// shard of the pool, so at high request rates it scales much better than a LockedSource.
+//
+// The shards are kept in a sync.Pool, that has a lock-free per-P fast path. Every shard is a
+// different stream of a StreamFactory, so the values generated by two shards never overlap (for at least
+// the jump distance of the generator). A shard dropped by the garbage collector is replaced by a new stream,
+// so the sequence of values is not reproducible: use a StreamFactory if you need per-worker reproducible streams.
+type ShardedSource struct {
+	mu      sync.Mutex // protects factory
+	factory *StreamFactory
+	shards  sync.Pool
+}
+
+// NewShardedSource return a new ShardedSource, whose shards are the non-overlapping streams of x.
+// x is not modified. It returns ErrNotCloneable if x can't be encoded and decoded, all the sub packages can.
+func NewShardedSource(x XorShiftExt) (*ShardedSource, error) {
+	f, err := NewStreamFactory(x)
+	if err != nil {
+		return nil, err
+	}
+	s := &ShardedSource{factory: f}
+	s.shards.New = func() interface{} {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.factory.Next()
+	}
+	return s, nil
+}
+
+// Uint64 returns the next pseudo random number generated by one of the shards. It's safe for concurrent use.
+func (s *ShardedSource) Uint64() uint64 {
+	x := s.shards.Get().(XorShiftExt)
+	v := x.Uint64()
+	s.shards.Put(x)
+	return v
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64. It's safe for concurrent use.
+func (s *ShardedSource) Int63() int64 {
+	return int64(s.Uint64() & (1<<63 - 1))
+}
+
+// Float64 returns a pseudo-random number in [0.0,1.0), with 53 random bits. It's safe for concurrent use.
+func (s *ShardedSource) Float64() float64 {
+	return internal.Float64(s.Uint64())
+}
+
+// Shards returns the number of shards created so far, i.e. the number of streams taken from the factory.
+func (s *ShardedSource) Shards() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.factory.Index()
+}
@@ -0,0 +1,99 @@
+package xorshift
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+)
+
+func TestShardedSource(t *testing.T) {
+	const goroutines, n = 16, 5000
+
+	s, err := NewShardedSource(xoroshiro256starstar.NewSource(SEED))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make([][]uint64, goroutines)
+	var wg sync.WaitGroup
+	for g := range values {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				values[g] = append(values[g], s.Uint64())
+				if f := s.Float64(); f < 0 || f >= 1 {
+					t.Errorf("Float64() = %v, out of [0, 1)", f)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if s.Shards() == 0 {
+		t.Errorf("Shards() = 0 after %d calls", goroutines*n)
+	}
+
+	// the shards are non-overlapping streams, so no value can be repeated
+	seen := make(map[uint64]bool, goroutines*n)
+	for _, v := range values {
+		for _, u := range v {
+			if seen[u] {
+				t.Fatalf("the value %#x was generated twice", u)
+			}
+			seen[u] = true
+		}
+	}
+}
+
+// parallel benchmarks, compare with math/rand global source
+
+func BenchmarkShardedSourceParallel(b *testing.B) {
+	s, _ := NewShardedSource(xoroshiro256starstar.NewSource(SEED))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = s.Uint64()
+		}
+	})
+}
+
+func BenchmarkShardedSourceFloat64Parallel(b *testing.B) {
+	s, _ := NewShardedSource(xoroshiro256starstar.NewSource(SEED))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = s.Float64()
+		}
+	})
+}
+
+func BenchmarkLockedSourceParallel(b *testing.B) {
+	l := NewLockedSource(xoroshiro256starstar.NewSource(SEED))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = l.Uint64()
+		}
+	})
+}
+
+func BenchmarkMathRandParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = rand.Uint64()
+		}
+	})
+}
+
+func BenchmarkMathRandFloat64Parallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = rand.Float64()
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package xorshift
+
+// The functions below use the unbiased bounded draws of Uint64n, so they don't waste the generated values
+// like rand.Rand's Shuffle and Perm. Shuffle, Perm and PartialShuffle don't allocate, Sample allocates
+// O(len(dst)) memory for the set of the values already chosen. The exact sequence of draws of every function
+// is documented, so that its output can be reproduced by any other implementation of the same algorithm.
+
+// Shuffle pseudo-randomizes the order of the elements of s, with the Fisher-Yates algorithm as
+// formulated by Durstenfeld: for i from len(s)-1 down to 1, swap s[i] and s[Uint64n(x, i+1)].
+func Shuffle[T any](s []T, x XorShift) {
+	for i := len(s) - 1; i > 0; i-- {
+		j := Uint64n(x, uint64(i+1))
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Perm fills p with a pseudo-random permutation of the integers [0, len(p)), without allocations,
+// with the "inside-out" Fisher-Yates algorithm: for i from 0 to len(p)-1, j = Uint64n(x, i+1),
+// p[i] = p[j] and p[j] = i.
+func Perm(p []int, x XorShift) {
+	for i := range p {
+		j := Uint64n(x, uint64(i+1))
+		p[i] = p[j]
+		p[j] = i
+	}
+}
+
+// PartialShuffle moves k pseudo-random elements of s to its first k positions, in random order, and returns s[:k]:
+// it's a uniform sample without replacement of k out of len(s) elements, obtained with the first k steps
+// of the Fisher-Yates algorithm: for i from 0 to k-1, swap s[i] and s[i+Uint64n(x, len(s)-i)].
+// It panics if k < 0 or k > len(s).
+func PartialShuffle[T any](s []T, k int, x XorShift) []T {
+	if k < 0 || k > len(s) {
+		panic("xorshift: invalid argument to PartialShuffle")
+	}
+	for i := 0; i < k; i++ {
+		j := i + int(Uint64n(x, uint64(len(s)-i)))
+		s[i], s[j] = s[j], s[i]
+	}
+	return s[:k]
+}
+
+// Sample fills dst with len(dst) distinct pseudo-random integers in [0, n), with Floyd's algorithm:
+// for j from n-len(dst) to n-1, t = Uint64n(x, j+1), and the next value is t, or j if t was already chosen.
+// Every subset is equally likely, but the order of the values isn't random (j can only be chosen at the
+// step j): use Shuffle if it's needed. It takes O(len(dst)) time and memory, even for a huge n,
+// so it's the way to go when len(dst) is much less than n. It panics if len(dst) > n.
+func Sample(dst []int, n int, x XorShift) {
+	k := len(dst)
+	if k > n {
+		panic("xorshift: invalid argument to Sample")
+	}
+	chosen := make(map[int]struct{}, k)
+	for i, j := 0, n-k; j < n; i, j = i+1, j+1 {
+		t := int(Uint64n(x, uint64(j+1)))
+		if _, ok := chosen[t]; ok {
+			t = j
+		}
+		chosen[t] = struct{}{}
+		dst[i] = t
+	}
+}
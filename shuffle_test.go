@@ -0,0 +1,154 @@
+package xorshift
+
+import (
+	"fmt"
+	"math/rand"
+	randv2 "math/rand/v2"
+	"testing"
+
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+)
+
+func TestShuffleReference(t *testing.T) {
+	// the output must not change across releases, see the documented algorithms
+	x := xoroshiro256starstar.NewSource(SEED)
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	Shuffle(s, x)
+	if got, want := fmt.Sprint(s), "[3 5 9 2 0 4 1 7 8 6]"; got != want {
+		t.Errorf("Shuffle = %s, expected %s", got, want)
+	}
+
+	x = xoroshiro256starstar.NewSource(SEED)
+	p := make([]int, 10)
+	Perm(p, x)
+	if got, want := fmt.Sprint(p), "[8 9 2 6 4 7 1 0 5 3]"; got != want {
+		t.Errorf("Perm = %s, expected %s", got, want)
+	}
+
+	x = xoroshiro256starstar.NewSource(SEED)
+	s = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if got, want := fmt.Sprint(PartialShuffle(s, 4, x)), "[6 9 1 4]"; got != want {
+		t.Errorf("PartialShuffle = %s, expected %s", got, want)
+	}
+	if !isPerm(s) {
+		t.Errorf("PartialShuffle lost some elements: %v", s)
+	}
+
+	x = xoroshiro256starstar.NewSource(SEED)
+	d := make([]int, 5)
+	Sample(d, 1000000, x)
+	if got, want := fmt.Sprint(d), "[621606 964181 921552 284609 821477]"; got != want {
+		t.Errorf("Sample = %s, expected %s", got, want)
+	}
+}
+
+// uniformOutcomes checks with a chi-squared test that the n outcomes of f, identified by a string, are
+// equally likely among the given number of possible outcomes.
+func uniformOutcomes(t *testing.T, name string, n, outcomes int, f func() string) {
+	t.Helper()
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		counts[f()]++
+	}
+	if len(counts) != outcomes {
+		t.Errorf("%s: %d different outcomes, expected %d", name, len(counts), outcomes)
+	}
+	e := float64(n) / float64(outcomes)
+	var chi2 float64
+	for _, c := range counts {
+		d := float64(c) - e
+		chi2 += d * d / e
+	}
+	if crit := chiSquaredCritical(outcomes - 1); chi2 > crit {
+		t.Errorf("%s: chi-squared = %.1f with %d degrees of freedom, critical value %.1f", name, chi2, outcomes-1, crit)
+	}
+}
+
+func TestShuffleUniform(t *testing.T) {
+	x := xoroshiro256starstar.NewSource(SEED)
+	uniformOutcomes(t, "Shuffle", 120000, 24, func() string {
+		s := []byte("abcd")
+		Shuffle(s, x)
+		return string(s)
+	})
+	p := make([]int, 4)
+	uniformOutcomes(t, "Perm", 120000, 24, func() string {
+		Perm(p, x)
+		return fmt.Sprint(p)
+	})
+	uniformOutcomes(t, "PartialShuffle", 120000, 20, func() string {
+		return string(PartialShuffle([]byte("abcde"), 2, x))
+	})
+	d := make([]int, 2)
+	uniformOutcomes(t, "Sample", 100000, 10, func() string {
+		Sample(d, 5, x)
+		if d[0] > d[1] { // the subsets, not the order
+			d[0], d[1] = d[1], d[0]
+		}
+		if d[0] == d[1] {
+			t.Fatalf("Sample returned %v, not distinct", d)
+		}
+		return fmt.Sprint(d)
+	})
+}
+
+func TestShuffleEdges(t *testing.T) {
+	x := xoroshiro256starstar.NewSource(SEED)
+	Shuffle([]int(nil), x)
+	Perm(nil, x)
+	if s := PartialShuffle([]int{1, 2, 3}, 0, x); len(s) != 0 {
+		t.Errorf("PartialShuffle(0) = %v", s)
+	}
+	d := make([]int, 7)
+	Sample(d, 7, x)
+	if !isPerm(d) {
+		t.Errorf("Sample of 7 out of 7 = %v, not a permutation", d)
+	}
+
+	expectPanic(t, "PartialShuffle 3 out of 2", func() { PartialShuffle([]int{1, 2}, 3, x) })
+	expectPanic(t, "PartialShuffle -1 out of 2", func() { PartialShuffle([]int{1, 2}, -1, x) })
+	expectPanic(t, "Sample 3 out of 2", func() { Sample(make([]int, 3), 2, x) })
+}
+
+func BenchmarkShuffle(b *testing.B) {
+	x := xoroshiro256starstar.NewSource(SEED)
+	s := make([]int, 1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Shuffle(s, x)
+	}
+}
+
+func BenchmarkMathRandShuffle(b *testing.B) {
+	r := rand.New(xoroshiro256starstar.NewSource(SEED))
+	s := make([]int, 1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+	}
+}
+
+func BenchmarkPerm(b *testing.B) {
+	x := xoroshiro256starstar.NewSource(SEED)
+	p := make([]int, 1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Perm(p, x)
+	}
+}
+
+func BenchmarkMathRandPerm(b *testing.B) {
+	r := rand.New(xoroshiro256starstar.NewSource(SEED))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = r.Perm(1000)
+	}
+}
+
+func BenchmarkMathRandV2Perm(b *testing.B) {
+	r := randv2.New(xoroshiro256starstar.NewSource(SEED))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = r.Perm(1000)
+	}
+}
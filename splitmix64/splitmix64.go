@@ -3,8 +3,12 @@ package splitmix64
 
 import (
 	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/seedseq"
 )
 
+// codec describes the SplitMix64 state for the encoding functions.
+var codec = internal.Codec{Name: "splitmix64", Words: 1, AllowZero: true}
+
 // SplitMix64 hold the state required by the SplitMix64 generator.
 type SplitMix64 struct {
 	is internal.SplitMix64
@@ -22,12 +26,124 @@ func (x *SplitMix64) Seed(seed int64) {
 	x.is.Seed(seed)
 }
 
+// NewSourceFromKey return a new SplitMix64 random number generator, with the internal state derived from key.
+func NewSourceFromKey(key []byte) *SplitMix64 {
+	tmpxs := SplitMix64{}
+	tmpxs.SeedBytes(key)
+	return &tmpxs
+}
+
+// SeedBytes use every bit of the provvided key (e.g. a string ID or a 256-bit key) to init SplitMix64 internal state.
+// The key-to-state expansion is stable: the same key gives always the same state. The key is hashed down to the 64 bits of the state.
+func (x *SplitMix64) SeedBytes(key []byte) {
+	var s [1]uint64
+	internal.ExpandKey(key, s[:])
+	x.is.Seed(int64(s[0]))
+}
+
+// NewSourceFromSeedSequence return a new SplitMix64 random number generator, with the internal state generated by ss.
+func NewSourceFromSeedSequence(ss *seedseq.SeedSequence) *SplitMix64 {
+	tmpxs := SplitMix64{}
+	tmpxs.SeedFrom(ss)
+	return &tmpxs
+}
+
+// SeedFrom init SplitMix64 internal state with the first 1 word generated by ss.
+func (x *SplitMix64) SeedFrom(ss *seedseq.SeedSequence) {
+	var s [1]uint64
+	ss.Fill(s[:])
+	x.is.Seed(int64(s[0]))
+}
+
+// NewSourceFromState return a new SplitMix64 random number generator with the given raw internal state,
+// as in the reference implementation. Every state, zero included, is valid.
+func NewSourceFromState(s uint64) *SplitMix64 {
+	tmpxs := SplitMix64{}
+	tmpxs.SetState(s)
+	return &tmpxs
+}
+
+// SetState sets the raw internal state of SplitMix64, as in the reference implementation.
+// Every state, zero included, is valid.
+func (x *SplitMix64) SetState(s uint64) {
+	x.is.Seed(int64(s))
+}
+
 // Uint64 returns the next pseudo random number generated, before start you must provvide one 64 unsigned bit seed.
 func (x *SplitMix64) Uint64() uint64 {
 	return x.is.Uint64()
 }
 
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *SplitMix64) Prev() uint64 {
+	return x.is.Prev()
+}
+
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
 func (x *SplitMix64) Int63() int64 {
 	return x.is.Int63()
 }
+
+// Float64 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-53 obtained from the upper 53 bits of Uint64().
+func (x *SplitMix64) Float64() float64 {
+	return internal.Float64(x.Uint64())
+}
+
+// Float64Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-53.
+func (x *SplitMix64) Float64Open() float64 {
+	return internal.Float64Open(x.Uint64())
+}
+
+// Float64Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^53-1).
+func (x *SplitMix64) Float64Closed() float64 {
+	return internal.Float64Closed(x.Uint64())
+}
+
+// Float32 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-24 obtained from the upper 24 bits of Uint64().
+func (x *SplitMix64) Float32() float32 {
+	return internal.Float32(x.Uint64())
+}
+
+// Float32Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-24.
+func (x *SplitMix64) Float32Open() float32 {
+	return internal.Float32Open(x.Uint64())
+}
+
+// Float32Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^24-1).
+func (x *SplitMix64) Float32Closed() float32 {
+	return internal.Float32Closed(x.Uint64())
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoded state
+// holds the version of the encoding and the name of the algorithm.
+func (x *SplitMix64) MarshalBinary() ([]byte, error) {
+	return codec.MarshalBinary([]uint64{x.is.State()}, 0), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, it returns an error
+// if data isn't a valid SplitMix64 state.
+func (x *SplitMix64) UnmarshalBinary(data []byte) error {
+	var s [1]uint64
+	if _, err := codec.UnmarshalBinary(data, s[:]); err != nil {
+		return err
+	}
+	x.is.Seed(int64(s[0]))
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so the state can be
+// embedded in JSON documents. The encoded state starts with the name of the algorithm.
+func (x *SplitMix64) MarshalText() ([]byte, error) {
+	return codec.MarshalText([]uint64{x.is.State()}, 0), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it returns an error
+// if text isn't a valid SplitMix64 state.
+func (x *SplitMix64) UnmarshalText(text []byte) error {
+	var s [1]uint64
+	if _, err := codec.UnmarshalText(text, s[:]); err != nil {
+		return err
+	}
+	x.is.Seed(int64(s[0]))
+	return nil
+}
@@ -0,0 +1,116 @@
+package xorshift
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vpxyz/xorshift/splitmix64"
+	"github.com/vpxyz/xorshift/xoroshiro128plus"
+	"github.com/vpxyz/xorshift/xoroshiro128starstar"
+	"github.com/vpxyz/xorshift/xoroshiro256plus"
+	"github.com/vpxyz/xorshift/xoroshiro256plusplus"
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+	"github.com/vpxyz/xorshift/xoroshiro512plus"
+	"github.com/vpxyz/xorshift/xoroshiro512starstar"
+	"github.com/vpxyz/xorshift/xorshift1024star"
+	"github.com/vpxyz/xorshift/xorshift1024starphi"
+	"github.com/vpxyz/xorshift/xorshift128plus"
+	"github.com/vpxyz/xorshift/xorshift4096star"
+	"github.com/vpxyz/xorshift/xorshift64star"
+)
+
+func TestNewSourceFromZeroState(t *testing.T) {
+	constructors := map[string]func() error{
+		"xorshift64*": func() error {
+			_, err := xorshift64star.NewSourceFromState(0)
+			return err
+		},
+		"xorshift128+": func() error {
+			_, err := xorshift128plus.NewSourceFromState([2]uint64{})
+			return err
+		},
+		"xoroshiro128+": func() error {
+			_, err := xoroshiro128plus.NewSourceFromState([2]uint64{})
+			return err
+		},
+		"xoroshiro128+ (2016)": func() error {
+			_, err := xoroshiro128plus.NewLegacySourceFromState([2]uint64{})
+			return err
+		},
+		"xoroshiro128**": func() error {
+			_, err := xoroshiro128starstar.NewSourceFromState([2]uint64{})
+			return err
+		},
+		"xoroshiro256+": func() error {
+			_, err := xoroshiro256plus.NewSourceFromState([4]uint64{})
+			return err
+		},
+		"xoroshiro256++": func() error {
+			_, err := xoroshiro256plusplus.NewSourceFromState([4]uint64{})
+			return err
+		},
+		"xoroshiro256**": func() error {
+			_, err := xoroshiro256starstar.NewSourceFromState([4]uint64{})
+			return err
+		},
+		"xoroshiro512+": func() error {
+			_, err := xoroshiro512plus.NewSourceFromState([8]uint64{})
+			return err
+		},
+		"xoroshiro512**": func() error {
+			_, err := xoroshiro512starstar.NewSourceFromState([8]uint64{})
+			return err
+		},
+		"xorshift1024*": func() error {
+			_, err := xorshift1024star.NewSourceFromState([16]uint64{})
+			return err
+		},
+		"xorshift1024*phi": func() error {
+			_, err := xorshift1024starphi.NewSourceFromState([16]uint64{})
+			return err
+		},
+		"xorshift4096*": func() error {
+			_, err := xorshift4096star.NewSourceFromState([64]uint64{})
+			return err
+		},
+	}
+
+	for name, f := range constructors {
+		if err := f(); !errors.Is(err, ErrZeroState) {
+			t.Errorf("%s: NewSourceFromState(zero state) error %v, want %v", name, err, ErrZeroState)
+		}
+	}
+}
+
+func TestSetStateKeepsStateOnError(t *testing.T) {
+	x := xoroshiro256starstar.NewSource(SEED)
+	y := xoroshiro256starstar.NewSource(SEED)
+	if err := x.SetState([4]uint64{}); !errors.Is(err, ErrZeroState) {
+		t.Errorf("SetState(zero state) error %v, want %v", err, ErrZeroState)
+	}
+	if !sameOutput(x, y, 10) {
+		t.Errorf("SetState(zero state) modified the generator")
+	}
+
+	if _, err := xoroshiro256starstar.NewSourceFromState([4]uint64{0, 0, 1, 0}); err != nil {
+		t.Errorf("NewSourceFromState() error %v", err)
+	}
+	if x := splitmix64.NewSourceFromState(0); x.Uint64() == 0 {
+		t.Errorf("splitmix64 with zero state generated a zero")
+	}
+}
+
+func TestXorShift64StarZeroSeed(t *testing.T) {
+	x := xorshift64star.NewSource(0)
+	for i := 0; i < 10; i++ {
+		if x.Uint64() == 0 {
+			t.Fatalf("XorShift64Star seeded with zero generated a zero")
+		}
+	}
+
+	y := xorshift64star.NewSource(SEED)
+	y.Seed(0)
+	if !sameOutput(xorshift64star.NewSource(0), y, 10) {
+		t.Errorf("Seed(0) and NewSource(0) generate different values")
+	}
+}
@@ -0,0 +1,93 @@
+package xorshift
+
+import (
+	"encoding"
+	"errors"
+	"reflect"
+)
+
+// ErrNotCloneable the generator can't be copied by a StreamFactory, because it doesn't
+// implement encoding.BinaryMarshaler and encoding.BinaryUnmarshaler.
+var ErrNotCloneable = errors.New("xorshift: the generator can't be cloned")
+
+// StreamFactory hands out non-overlapping streams of a generator, for parallel computations.
+// The n-th stream is a copy of the base generator moved ahead of n calls to Jump(),
+// so every stream is disjoint from the others for at least the jump distance of the generator
+// (e.g. 2^128 values for xoroshiro256**). The base generator is copied, and never modified.
+//
+// NOTE: Not concurrency-safe! Hand out the streams from a single goroutine, then give each one to a worker.
+type StreamFactory struct {
+	typ  reflect.Type
+	base []byte      // encoded state of the stream 0
+	cur  XorShiftExt // the next stream returned by Next
+	next uint64      // index of cur
+}
+
+// NewStreamFactory return a new StreamFactory that hands out the streams of x, starting from the stream 0.
+// x is not modified. It returns ErrNotCloneable if x can't be encoded and decoded, all the sub packages can.
+func NewStreamFactory(x XorShiftExt) (*StreamFactory, error) {
+	m, ok := x.(encoding.BinaryMarshaler)
+	if !ok || reflect.TypeOf(x).Kind() != reflect.Ptr {
+		return nil, ErrNotCloneable
+	}
+	if _, ok := x.(encoding.BinaryUnmarshaler); !ok {
+		return nil, ErrNotCloneable
+	}
+	base, err := m.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &StreamFactory{typ: reflect.TypeOf(x).Elem(), base: base}
+	f.cur = f.clone(base)
+	return f, nil
+}
+
+// clone returns a new generator with the encoded state.
+func (f *StreamFactory) clone(state []byte) XorShiftExt {
+	x := reflect.New(f.typ).Interface().(XorShiftExt)
+	if err := x.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+		// the state was produced by a generator of the same type
+		panic("xorshift: can't clone the generator: " + err.Error())
+	}
+	return x
+}
+
+// Stream returns a new generator at the beginning of the n-th stream. It doesn't change the
+// stream returned by Next. If the generator implements XorShiftStreamJumper, the stream is
+// reached with JumpN(n), in O(log n) time for a large n, otherwise with n calls to Jump().
+func (f *StreamFactory) Stream(n uint64) XorShiftExt {
+	x := f.clone(f.base)
+	if j, ok := x.(XorShiftStreamJumper); ok {
+		j.JumpN(n)
+		return x
+	}
+	for i := uint64(0); i < n; i++ {
+		x.Jump()
+	}
+	return x
+}
+
+// Next returns a new generator at the beginning of the next stream, the first call returns the stream 0
+// (or the one set by Resume).
+func (f *StreamFactory) Next() XorShiftExt {
+	state, err := f.cur.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		panic("xorshift: can't clone the generator: " + err.Error())
+	}
+	f.cur.Jump()
+	f.next++
+	return f.clone(state)
+}
+
+// Resume sets the index of the stream returned by the next call to Next, e.g. to restart
+// a computation from a checkpoint without replaying the streams handed out before.
+func (f *StreamFactory) Resume(n uint64) {
+	f.cur = f.Stream(n)
+	f.next = n
+}
+
+// Index returns the index of the stream returned by the next call to Next.
+func (f *StreamFactory) Index() uint64 {
+	return f.next
+}
@@ -0,0 +1,89 @@
+package xorshift
+
+import (
+	"testing"
+
+	"github.com/vpxyz/xorshift/xoroshiro256starstar"
+)
+
+// jumpOnly is a xoroshiro256** without JumpN, to test the StreamFactory with a generator that can only Jump.
+type jumpOnly struct {
+	x xoroshiro256starstar.XoroShiro256StarStar
+}
+
+func (j *jumpOnly) Seed(seed int64)                   { j.x.Seed(seed) }
+func (j *jumpOnly) Uint64() uint64                    { return j.x.Uint64() }
+func (j *jumpOnly) Jump()                             { j.x.Jump() }
+func (j *jumpOnly) MarshalBinary() ([]byte, error)    { return j.x.MarshalBinary() }
+func (j *jumpOnly) UnmarshalBinary(data []byte) error { return j.x.UnmarshalBinary(data) }
+
+func TestStreamFactory(t *testing.T) {
+	for _, base := range []XorShiftExt{xoroshiro256starstar.NewSource(1), &jumpOnly{*xoroshiro256starstar.NewSource(1)}} {
+		f, err := NewStreamFactory(base)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 5; i++ {
+			ref := xoroshiro256starstar.NewSource(1)
+			for j := 0; j < i; j++ {
+				ref.Jump()
+			}
+			if !sameOutput(f.Next(), ref, 16) {
+				t.Errorf("%T: stream %d differs from %d calls to Jump()", base, i, i)
+			}
+		}
+		if f.Index() != 5 {
+			t.Errorf("%T: Index() = %d, expected 5", base, f.Index())
+		}
+		if !sameOutput(base, xoroshiro256starstar.NewSource(1), 16) {
+			t.Errorf("%T: the base generator was modified", base)
+		}
+
+		f.Resume(100)
+		if !sameOutput(f.Next(), f.Stream(100), 16) {
+			t.Errorf("%T: Next() after Resume(100) differs from Stream(100)", base)
+		}
+		if f.Index() != 101 {
+			t.Errorf("%T: Index() = %d, expected 101", base, f.Index())
+		}
+		if !sameOutput(f.Next(), f.Stream(101), 16) {
+			t.Errorf("%T: Next() differs from Stream(101)", base)
+		}
+	}
+
+	if _, err := NewStreamFactory(&struct{ XorShiftExt }{xoroshiro256starstar.NewSource(1)}); err != ErrNotCloneable {
+		t.Errorf("NewStreamFactory of a generator that can't be encoded: %v, expected ErrNotCloneable", err)
+	}
+}
+
+func TestStreamFactoryJumps(t *testing.T) {
+	// Stream and Resume must reach the same state of n calls to Jump(), on every generator
+	for _, c := range conformanceCases {
+		base, ok := c.load(c.state).(XorShiftExt)
+		if !ok {
+			continue
+		}
+		f, err := NewStreamFactory(base)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		for _, n := range []uint64{1, 3, 20} {
+			ref := func() XorShift {
+				x := c.load(c.state).(XorShiftExt)
+				for i := uint64(0); i < n; i++ {
+					x.Jump()
+				}
+				return x
+			}
+			if !sameOutput(f.Stream(n), ref(), 8) {
+				t.Errorf("%s: Stream(%d) differs from %d calls to Jump()", c.name, n, n)
+			}
+			f.Resume(n)
+			if !sameOutput(f.Next(), ref(), 8) {
+				t.Errorf("%s: Next() after Resume(%d) differs from %d calls to Jump()", c.name, n, n)
+			}
+		}
+	}
+}
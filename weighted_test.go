@@ -0,0 +1,162 @@
+package xorshift
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vpxyz/xorshift/distributions"
+	"github.com/vpxyz/xorshift/xoroshiro256plusplus"
+)
+
+// chiSquaredWeights tests the n indexes drawn from a xoroshiro256++ against the weights w.
+func chiSquaredWeights(t *testing.T, name string, n int, draw func(distributions.Source) int, w []float64) {
+	t.Helper()
+	x := xoroshiro256plusplus.NewSource(SEED)
+	counts := map[int]int{}
+	for i := 0; i < n; i++ {
+		counts[draw(x)]++
+	}
+	chiSquaredCounts(t, name, n, counts, weightsPMF(w), 0, len(w)-1)
+}
+
+// weightsPMF returns the probability mass function of the given weights.
+func weightsPMF(w []float64) func(k int) float64 {
+	var sum float64
+	for _, v := range w {
+		sum += v
+	}
+	return func(k int) float64 {
+		if k < 0 || k >= len(w) {
+			return 0
+		}
+		return w[k] / sum
+	}
+}
+
+// testWeights returns n weights with very different magnitudes, and some zeros.
+func testWeights(n int) []float64 {
+	x := xoroshiro256plusplus.NewSource(1)
+	w := make([]float64, n)
+	for i := range w {
+		if i%7 == 3 {
+			continue
+		}
+		w[i] = distributions.Pareto(x, 1, 1.5)
+	}
+	return w
+}
+
+func TestAlias(t *testing.T) {
+	for _, w := range [][]float64{{1}, {1, 0, 3}, {0.1, 0.2, 0.3, 0.4}, testWeights(1000), testWeights(100000)} {
+		a, err := distributions.NewAlias(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Len() != len(w) {
+			t.Errorf("Len() = %d, expected %d", a.Len(), len(w))
+		}
+		chiSquaredWeights(t, "Alias", 1000000, a.Draw, w)
+	}
+}
+
+func TestFenwick(t *testing.T) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	w := testWeights(1000)
+	f, err := distributions.NewFenwick(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chiSquaredWeights(t, "Fenwick", 1000000, f.Draw, w)
+
+	// change some weights, the distribution must follow
+	for i := 0; i < len(w); i += 3 {
+		w[i] = float64(i % 11)
+		if err := f.Set(i, w[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if f.Weight(9) != w[9] {
+		t.Errorf("Weight(9) = %v, expected %v", f.Weight(9), w[9])
+	}
+	var sum float64
+	for _, v := range w {
+		sum += v
+	}
+	if d := f.Total() - sum; d > 1e-9*sum || d < -1e-9*sum {
+		t.Errorf("Total() = %v, expected %v", f.Total(), sum)
+	}
+	chiSquaredWeights(t, "Fenwick after Set", 1000000, f.Draw, w)
+
+	// a single weight
+	for i := range w {
+		f.Set(i, 0)
+	}
+	f.Set(len(w)-1, 2)
+	for i := 0; i < 100; i++ {
+		if v := f.Draw(x); v != len(w)-1 {
+			t.Fatalf("Draw() = %d, the only nonzero weight is %d", v, len(w)-1)
+		}
+	}
+	if err := f.Set(0, -1); err != distributions.ErrInvalidWeights {
+		t.Errorf("Set(0, -1): %v, expected ErrInvalidWeights", err)
+	}
+
+	// all the weights set to zero one at a time
+	f, _ = distributions.NewFenwick([]float64{0.1, 0.2})
+	f.Set(0, 0)
+	f.Set(1, 0)
+	if v := f.Total(); v != 0 {
+		t.Errorf("Total() = %g with all the weights zero", v)
+	}
+	expectPanic(t, "Draw with all the weights zero", func() { f.Draw(x) })
+
+	// removing a large weight must not cancel out the small ones
+	f, _ = distributions.NewFenwick([]float64{1e20, 1, 1})
+	f.Set(0, 0)
+	if v := f.Total(); v != 2 {
+		t.Errorf("Total() = %g after removing the large weight, expected 2", v)
+	}
+	chiSquaredWeights(t, "Fenwick after removing the large weight", 10000, f.Draw, []float64{0, 1, 1})
+
+	// a weight that makes the sum overflow is rejected, like by NewFenwick
+	f, _ = distributions.NewFenwick([]float64{1e308, 1})
+	if err := f.Set(1, 1e308); err != distributions.ErrInvalidWeights {
+		t.Errorf("Set(1, 1e308): %v, expected ErrInvalidWeights", err)
+	}
+	if f.Weight(1) != 1 || f.Total() != 1e308 {
+		t.Errorf("Set(1, 1e308) changed the weights: %v, total %v", f.Weight(1), f.Total())
+	}
+	chiSquaredWeights(t, "Fenwick after an overflowing Set", 10000, f.Draw, []float64{1e308, 1})
+}
+
+func TestWeightsInvalid(t *testing.T) {
+	for _, w := range [][]float64{nil, {0, 0}, {1, -1}, {1, nan()}, {1, inf()}} {
+		if _, err := distributions.NewAlias(w); err != distributions.ErrInvalidWeights {
+			t.Errorf("NewAlias(%v): %v, expected ErrInvalidWeights", w, err)
+		}
+		if _, err := distributions.NewFenwick(w); err != distributions.ErrInvalidWeights {
+			t.Errorf("NewFenwick(%v): %v, expected ErrInvalidWeights", w, err)
+		}
+	}
+}
+
+func nan() float64 { return math.NaN() }
+func inf() float64 { return math.Inf(1) }
+
+func BenchmarkAlias(b *testing.B) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	a, _ := distributions.NewAlias(testWeights(100000))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = a.Draw(x)
+	}
+}
+
+func BenchmarkFenwick(b *testing.B) {
+	x := xoroshiro256plusplus.NewSource(SEED)
+	f, _ := distributions.NewFenwick(testWeights(100000))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = f.Draw(x)
+	}
+}
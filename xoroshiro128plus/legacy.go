@@ -0,0 +1,250 @@
+package xoroshiro128plus
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/seedseq"
+)
+
+var (
+	// legacyJumpPoly "const" for XoroShiro128PlusLegacy Jump function
+	legacyJumpPoly = []uint64{0xbeac0467eba5facb, 0xd86b048b86aa9922}
+
+	// legacyLongJumpPoly "const" for XoroShiro128PlusLegacy LongJump function
+	legacyLongJumpPoly = []uint64{0x18f7c399ccebda8d, 0xf2deac28bef3bb07}
+)
+
+// legacyAdvancer computes the jump polynomials used by XoroShiro128PlusLegacy Advance.
+var legacyAdvancer = internal.NewAdvancer(128, func() func() uint64 {
+	x := NewLegacySource(1)
+	return func() uint64 {
+		x.Uint64()
+		return x.s[0]
+	}
+})
+
+// legacyCodec describes the XoroShiro128PlusLegacy state for the encoding functions.
+var legacyCodec = internal.Codec{Name: "xoroshiro128+2016", Words: 2}
+
+// XoroShiro128PlusLegacy holds the state required by XoroShiro128PlusLegacy generator.
+// It's the original (2016) version of xoroshiro128+, use it only to reproduce existing streams.
+type XoroShiro128PlusLegacy struct {
+	// The state must be seeded with a nonzero value. Require 2 64-bit unsigned values.
+	// The state must be seeded so that it is not everywhere zero. The state are filled using
+	// the SplitMix64 generator with the provvided seed.
+	s [2]uint64
+}
+
+// NewLegacySource return a new XoroShiro128PlusLegacy random number generator
+func NewLegacySource(seed int64) *XoroShiro128PlusLegacy {
+	tmpxs := XoroShiro128PlusLegacy{}
+	tmpxs.Seed(seed)
+	return &tmpxs
+}
+
+// Seed use the provvided seed value to init XoroShiro128PlusLegacy internal state.
+func (x *XoroShiro128PlusLegacy) Seed(seed int64) {
+	tmpxs := internal.SplitMix64{}
+	tmpxs.Seed(seed)
+
+	for i := 0; i < len(x.s); i++ {
+		x.s[i] = tmpxs.Uint64()
+
+	}
+}
+
+// NewLegacySourceFromKey return a new XoroShiro128PlusLegacy random number generator, with the internal state derived from key.
+func NewLegacySourceFromKey(key []byte) *XoroShiro128PlusLegacy {
+	tmpxs := XoroShiro128PlusLegacy{}
+	tmpxs.SeedBytes(key)
+	return &tmpxs
+}
+
+// SeedBytes use every bit of the provvided key (e.g. a string ID or a 256-bit key) to init XoroShiro128PlusLegacy internal state.
+// The key-to-state expansion is stable: the same key gives always the same state. Keys up to 128 bits can give distinct states.
+func (x *XoroShiro128PlusLegacy) SeedBytes(key []byte) {
+	internal.ExpandKey(key, x.s[:])
+}
+
+// NewLegacySourceFromSeedSequence return a new XoroShiro128PlusLegacy random number generator, with the internal state generated by ss.
+func NewLegacySourceFromSeedSequence(ss *seedseq.SeedSequence) *XoroShiro128PlusLegacy {
+	tmpxs := XoroShiro128PlusLegacy{}
+	tmpxs.SeedFrom(ss)
+	return &tmpxs
+}
+
+// SeedFrom init XoroShiro128PlusLegacy internal state with the first 2 words generated by ss.
+func (x *XoroShiro128PlusLegacy) SeedFrom(ss *seedseq.SeedSequence) {
+	ss.Fill(x.s[:])
+	internal.AvoidZero(x.s[:])
+}
+
+// NewLegacySourceFromState return a new XoroShiro128PlusLegacy random number generator with the given raw internal state,
+// as in the reference implementation. It returns an error if the state is everywhere zero.
+func NewLegacySourceFromState(s [2]uint64) (*XoroShiro128PlusLegacy, error) {
+	tmpxs := XoroShiro128PlusLegacy{}
+	if err := tmpxs.SetState(s); err != nil {
+		return nil, err
+	}
+	return &tmpxs, nil
+}
+
+// SetState sets the raw internal state of XoroShiro128PlusLegacy, as in the reference implementation.
+// It returns an error, leaving the generator unchanged, if the state is everywhere zero.
+func (x *XoroShiro128PlusLegacy) SetState(s [2]uint64) error {
+	if internal.IsZero(s[:]) {
+		return internal.ErrZeroState
+	}
+	x.s = s
+	return nil
+}
+
+// Uint64 returns the next pseudo random number generated, before start you must provvide seed.
+func (x *XoroShiro128PlusLegacy) Uint64() uint64 {
+	s0, s1 := x.s[0], x.s[1]
+	r := s0 + s1
+
+	s1 ^= s0
+
+	// update the generator state
+	x.s[0] = bits.RotateLeft64(s0, 55) ^ s1 ^ (s1 << 14) // a, b
+	x.s[1] = bits.RotateLeft64(s1, 36)                   // c
+
+	return r
+}
+
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *XoroShiro128PlusLegacy) Prev() uint64 {
+	// undo the state update
+	t := bits.RotateLeft64(x.s[1], -36)            // c
+	s0 := bits.RotateLeft64(x.s[0]^t^(t<<14), -55) // a, b
+	s1 := t ^ s0
+
+	x.s[0], x.s[1] = s0, s1
+
+	return s0 + s1
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+func (x *XoroShiro128PlusLegacy) Int63() int64 {
+	return int64(x.Uint64() >> 1)
+
+}
+
+// Float64 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-53 obtained from the upper 53 bits of Uint64().
+func (x *XoroShiro128PlusLegacy) Float64() float64 {
+	return internal.Float64(x.Uint64())
+}
+
+// Float64Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-53.
+func (x *XoroShiro128PlusLegacy) Float64Open() float64 {
+	return internal.Float64Open(x.Uint64())
+}
+
+// Float64Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^53-1).
+func (x *XoroShiro128PlusLegacy) Float64Closed() float64 {
+	return internal.Float64Closed(x.Uint64())
+}
+
+// Float32 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-24 obtained from the upper 24 bits of Uint64().
+func (x *XoroShiro128PlusLegacy) Float32() float32 {
+	return internal.Float32(x.Uint64())
+}
+
+// Float32Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-24.
+func (x *XoroShiro128PlusLegacy) Float32Open() float32 {
+	return internal.Float32Open(x.Uint64())
+}
+
+// Float32Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^24-1).
+func (x *XoroShiro128PlusLegacy) Float32Closed() float32 {
+	return internal.Float32Closed(x.Uint64())
+}
+
+// Jump it is equivalent to 2^64 calls to Uint64().
+func (x *XoroShiro128PlusLegacy) Jump() {
+	x.jump(legacyJumpPoly)
+}
+
+// JumpN it is equivalent to n calls to Jump(), that is n*2^64 calls to Uint64().
+// For a large n the jump polynomial is raised to the n-th power, with O(log n) multiplications.
+func (x *XoroShiro128PlusLegacy) JumpN(n uint64) {
+	if n < internal.SequentialJumps {
+		for ; n > 0; n-- {
+			x.Jump()
+		}
+		return
+	}
+	x.jump(legacyAdvancer.PowPoly(legacyJumpPoly, n))
+}
+
+// LongJump it is equivalent to 2^96 calls to Uint64().
+func (x *XoroShiro128PlusLegacy) LongJump() {
+	x.jump(legacyLongJumpPoly)
+}
+
+// Advance it is equivalent to n calls to Uint64().
+func (x *XoroShiro128PlusLegacy) Advance(n uint64) {
+	x.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig it is equivalent to n calls to Uint64(). The jump is computed modulo
+// the period of the generator, 2^128 - 1, so a negative n moves the generator backward.
+func (x *XoroShiro128PlusLegacy) AdvanceBig(n *big.Int) {
+	x.jump(legacyAdvancer.JumpPoly(n))
+}
+
+// jump moves the state ahead as stated by the given jump polynomial.
+func (x *XoroShiro128PlusLegacy) jump(poly []uint64) {
+	var s0, s1 uint64
+	var b uint64
+
+	for i := 0; i < len(poly); i++ {
+		for b = 0; b < 64; b++ {
+			if poly[i]&(uint64(1)<<b) != 0 {
+				s1 ^= x.s[1]
+				s0 ^= x.s[0]
+			}
+			x.Uint64()
+		}
+	}
+
+	x.s[1] = s1
+	x.s[0] = s0
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoded state
+// holds the version of the encoding and the name of the algorithm.
+func (x *XoroShiro128PlusLegacy) MarshalBinary() ([]byte, error) {
+	return legacyCodec.MarshalBinary(x.s[:], 0), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, it returns an error
+// if data isn't a valid XoroShiro128PlusLegacy state.
+func (x *XoroShiro128PlusLegacy) UnmarshalBinary(data []byte) error {
+	var s [2]uint64
+	if _, err := legacyCodec.UnmarshalBinary(data, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so the state can be
+// embedded in JSON documents. The encoded state starts with the name of the algorithm.
+func (x *XoroShiro128PlusLegacy) MarshalText() ([]byte, error) {
+	return legacyCodec.MarshalText(x.s[:], 0), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it returns an error
+// if text isn't a valid XoroShiro128PlusLegacy state.
+func (x *XoroShiro128PlusLegacy) UnmarshalText(text []byte) error {
+	var s [2]uint64
+	if _, err := legacyCodec.UnmarshalText(text, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
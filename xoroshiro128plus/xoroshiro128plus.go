@@ -1,12 +1,41 @@
-// Package xoroshiro128plus (XOR/rotate/shift/rotate) is the successor to xorshift128+, fastest generator for floating-point numbers.
+/*
+Package xoroshiro128plus (XOR/rotate/shift/rotate) is the successor to xorshift128+, fastest generator for floating-point numbers.
+
+The package provides two variants of the generator:
+XoroShiro128Plus uses the current (2018) parameters a=24, b=16, c=37 recommended by the reference implementation,
+XoroShiro128PlusLegacy uses the original (2016) parameters a=55, b=14, c=36, so the streams generated by
+the previous versions of this package can still be reproduced.
+*/
 package xoroshiro128plus
 
 import (
+	"math/big"
 	"math/bits"
 
 	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/seedseq"
+)
+
+var (
+	// jumpPoly "const" for Jump function
+	jumpPoly = []uint64{0xdf900294d8f554a5, 0x170865df4b3201fc}
+
+	// longJumpPoly "const" for LongJump function
+	longJumpPoly = []uint64{0xd2a98b26625eee7b, 0xdddf9b1090aa7ac1}
 )
 
+// advancer computes the jump polynomials used by XoroShiro128Plus Advance.
+var advancer = internal.NewAdvancer(128, func() func() uint64 {
+	x := NewSource(1)
+	return func() uint64 {
+		x.Uint64()
+		return x.s[0]
+	}
+})
+
+// codec describes the XoroShiro128Plus state for the encoding functions.
+var codec = internal.Codec{Name: "xoroshiro128+", Words: 2}
+
 // XoroShiro128Plus holds the state required by XoroShiro128Plus generator
 type XoroShiro128Plus struct {
 	// The state must be seeded with a nonzero value. Require 2 64-bit unsigned values.
@@ -33,6 +62,52 @@ func (x *XoroShiro128Plus) Seed(seed int64) {
 	}
 }
 
+// NewSourceFromKey return a new XoroShiro128Plus random number generator, with the internal state derived from key.
+func NewSourceFromKey(key []byte) *XoroShiro128Plus {
+	tmpxs := XoroShiro128Plus{}
+	tmpxs.SeedBytes(key)
+	return &tmpxs
+}
+
+// SeedBytes use every bit of the provvided key (e.g. a string ID or a 256-bit key) to init XoroShiro128Plus internal state.
+// The key-to-state expansion is stable: the same key gives always the same state. Keys up to 128 bits can give distinct states.
+func (x *XoroShiro128Plus) SeedBytes(key []byte) {
+	internal.ExpandKey(key, x.s[:])
+}
+
+// NewSourceFromSeedSequence return a new XoroShiro128Plus random number generator, with the internal state generated by ss.
+func NewSourceFromSeedSequence(ss *seedseq.SeedSequence) *XoroShiro128Plus {
+	tmpxs := XoroShiro128Plus{}
+	tmpxs.SeedFrom(ss)
+	return &tmpxs
+}
+
+// SeedFrom init XoroShiro128Plus internal state with the first 2 words generated by ss.
+func (x *XoroShiro128Plus) SeedFrom(ss *seedseq.SeedSequence) {
+	ss.Fill(x.s[:])
+	internal.AvoidZero(x.s[:])
+}
+
+// NewSourceFromState return a new XoroShiro128Plus random number generator with the given raw internal state,
+// as in the reference implementation. It returns an error if the state is everywhere zero.
+func NewSourceFromState(s [2]uint64) (*XoroShiro128Plus, error) {
+	tmpxs := XoroShiro128Plus{}
+	if err := tmpxs.SetState(s); err != nil {
+		return nil, err
+	}
+	return &tmpxs, nil
+}
+
+// SetState sets the raw internal state of XoroShiro128Plus, as in the reference implementation.
+// It returns an error, leaving the generator unchanged, if the state is everywhere zero.
+func (x *XoroShiro128Plus) SetState(s [2]uint64) error {
+	if internal.IsZero(s[:]) {
+		return internal.ErrZeroState
+	}
+	x.s = s
+	return nil
+}
+
 // Uint64 returns the next pseudo random number generated, before start you must provvide seed.
 func (x *XoroShiro128Plus) Uint64() uint64 {
 	s0, s1 := x.s[0], x.s[1]
@@ -41,26 +116,101 @@ func (x *XoroShiro128Plus) Uint64() uint64 {
 	s1 ^= s0
 
 	// update the generator state
-	x.s[0] = bits.RotateLeft64(s0, 55) ^ s1 ^ (s1 << 14) // a,b
-	x.s[1] = bits.RotateLeft64(s1, 36)
+	x.s[0] = bits.RotateLeft64(s0, 24) ^ s1 ^ (s1 << 16) // a, b
+	x.s[1] = bits.RotateLeft64(s1, 37)                   // c
 
 	return r
 }
 
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *XoroShiro128Plus) Prev() uint64 {
+	// undo the state update
+	t := bits.RotateLeft64(x.s[1], -37)            // c
+	s0 := bits.RotateLeft64(x.s[0]^t^(t<<16), -24) // a, b
+	s1 := t ^ s0
+
+	x.s[0], x.s[1] = s0, s1
+
+	return s0 + s1
+}
+
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
 func (x *XoroShiro128Plus) Int63() int64 {
 	return int64(x.Uint64() >> 1)
 
 }
 
+// Float64 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-53 obtained from the upper 53 bits of Uint64().
+func (x *XoroShiro128Plus) Float64() float64 {
+	return internal.Float64(x.Uint64())
+}
+
+// Float64Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-53.
+func (x *XoroShiro128Plus) Float64Open() float64 {
+	return internal.Float64Open(x.Uint64())
+}
+
+// Float64Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^53-1).
+func (x *XoroShiro128Plus) Float64Closed() float64 {
+	return internal.Float64Closed(x.Uint64())
+}
+
+// Float32 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-24 obtained from the upper 24 bits of Uint64().
+func (x *XoroShiro128Plus) Float32() float32 {
+	return internal.Float32(x.Uint64())
+}
+
+// Float32Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-24.
+func (x *XoroShiro128Plus) Float32Open() float32 {
+	return internal.Float32Open(x.Uint64())
+}
+
+// Float32Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^24-1).
+func (x *XoroShiro128Plus) Float32Closed() float32 {
+	return internal.Float32Closed(x.Uint64())
+}
+
 // Jump it is equivalent to 2^64 calls to Uint64().
 func (x *XoroShiro128Plus) Jump() {
-	var s0, s1 uint64 = 0, 0
+	x.jump(jumpPoly)
+}
+
+// JumpN it is equivalent to n calls to Jump(), that is n*2^64 calls to Uint64().
+// For a large n the jump polynomial is raised to the n-th power, with O(log n) multiplications.
+func (x *XoroShiro128Plus) JumpN(n uint64) {
+	if n < internal.SequentialJumps {
+		for ; n > 0; n-- {
+			x.Jump()
+		}
+		return
+	}
+	x.jump(advancer.PowPoly(jumpPoly, n))
+}
+
+// LongJump it is equivalent to 2^96 calls to Uint64().
+func (x *XoroShiro128Plus) LongJump() {
+	x.jump(longJumpPoly)
+}
+
+// Advance it is equivalent to n calls to Uint64().
+func (x *XoroShiro128Plus) Advance(n uint64) {
+	x.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig it is equivalent to n calls to Uint64(). The jump is computed modulo
+// the period of the generator, 2^128 - 1, so a negative n moves the generator backward.
+func (x *XoroShiro128Plus) AdvanceBig(n *big.Int) {
+	x.jump(advancer.JumpPoly(n))
+}
+
+// jump moves the state ahead as stated by the given jump polynomial.
+func (x *XoroShiro128Plus) jump(poly []uint64) {
+	var s0, s1 uint64
 	var b uint64
 
-	for i := 0; i < len(internal.Jump128); i++ {
+	for i := 0; i < len(poly); i++ {
 		for b = 0; b < 64; b++ {
-			if internal.Jump128[i]&(uint64(1)<<b) != 0 {
+			if poly[i]&(uint64(1)<<b) != 0 {
 				s1 ^= x.s[1]
 				s0 ^= x.s[0]
 			}
@@ -71,3 +221,37 @@ func (x *XoroShiro128Plus) Jump() {
 	x.s[1] = s1
 	x.s[0] = s0
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoded state
+// holds the version of the encoding and the name of the algorithm.
+func (x *XoroShiro128Plus) MarshalBinary() ([]byte, error) {
+	return codec.MarshalBinary(x.s[:], 0), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, it returns an error
+// if data isn't a valid XoroShiro128Plus state.
+func (x *XoroShiro128Plus) UnmarshalBinary(data []byte) error {
+	var s [2]uint64
+	if _, err := codec.UnmarshalBinary(data, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so the state can be
+// embedded in JSON documents. The encoded state starts with the name of the algorithm.
+func (x *XoroShiro128Plus) MarshalText() ([]byte, error) {
+	return codec.MarshalText(x.s[:], 0), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it returns an error
+// if text isn't a valid XoroShiro128Plus state.
+func (x *XoroShiro128Plus) UnmarshalText(text []byte) error {
+	var s [2]uint64
+	if _, err := codec.UnmarshalText(text, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
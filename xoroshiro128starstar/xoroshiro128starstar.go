@@ -2,11 +2,33 @@
 package xoroshiro128starstar
 
 import (
+	"math/big"
 	"math/bits"
 
 	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/seedseq"
 )
 
+var (
+	// jumpPoly "const" for Jump function
+	jumpPoly = []uint64{0xdf900294d8f554a5, 0x170865df4b3201fc}
+
+	// longJumpPoly "const" for LongJump function
+	longJumpPoly = []uint64{0xd2a98b26625eee7b, 0xdddf9b1090aa7ac1}
+)
+
+// advancer computes the jump polynomials used by XoroShiro128StarStar Advance.
+var advancer = internal.NewAdvancer(128, func() func() uint64 {
+	x := NewSource(1)
+	return func() uint64 {
+		x.Uint64()
+		return x.s[0]
+	}
+})
+
+// codec describes the XoroShiro128StarStar state for the encoding functions.
+var codec = internal.Codec{Name: "xoroshiro128**", Words: 2}
+
 // XoroShiro128StarStar holds the state required by XoroShiro128StarStar generator
 type XoroShiro128StarStar struct {
 	// The state must be seeded with a nonzero value. Require 2 64-bit unsigned values.
@@ -33,6 +55,52 @@ func (x *XoroShiro128StarStar) Seed(seed int64) {
 	}
 }
 
+// NewSourceFromKey return a new XoroShiro128StarStar random number generator, with the internal state derived from key.
+func NewSourceFromKey(key []byte) *XoroShiro128StarStar {
+	tmpxs := XoroShiro128StarStar{}
+	tmpxs.SeedBytes(key)
+	return &tmpxs
+}
+
+// SeedBytes use every bit of the provvided key (e.g. a string ID or a 256-bit key) to init XoroShiro128StarStar internal state.
+// The key-to-state expansion is stable: the same key gives always the same state. Keys up to 128 bits can give distinct states.
+func (x *XoroShiro128StarStar) SeedBytes(key []byte) {
+	internal.ExpandKey(key, x.s[:])
+}
+
+// NewSourceFromSeedSequence return a new XoroShiro128StarStar random number generator, with the internal state generated by ss.
+func NewSourceFromSeedSequence(ss *seedseq.SeedSequence) *XoroShiro128StarStar {
+	tmpxs := XoroShiro128StarStar{}
+	tmpxs.SeedFrom(ss)
+	return &tmpxs
+}
+
+// SeedFrom init XoroShiro128StarStar internal state with the first 2 words generated by ss.
+func (x *XoroShiro128StarStar) SeedFrom(ss *seedseq.SeedSequence) {
+	ss.Fill(x.s[:])
+	internal.AvoidZero(x.s[:])
+}
+
+// NewSourceFromState return a new XoroShiro128StarStar random number generator with the given raw internal state,
+// as in the reference implementation. It returns an error if the state is everywhere zero.
+func NewSourceFromState(s [2]uint64) (*XoroShiro128StarStar, error) {
+	tmpxs := XoroShiro128StarStar{}
+	if err := tmpxs.SetState(s); err != nil {
+		return nil, err
+	}
+	return &tmpxs, nil
+}
+
+// SetState sets the raw internal state of XoroShiro128StarStar, as in the reference implementation.
+// It returns an error, leaving the generator unchanged, if the state is everywhere zero.
+func (x *XoroShiro128StarStar) SetState(s [2]uint64) error {
+	if internal.IsZero(s[:]) {
+		return internal.ErrZeroState
+	}
+	x.s = s
+	return nil
+}
+
 // Uint64 returns the next pseudo random number generated, before start you must provvide seed.
 func (x *XoroShiro128StarStar) Uint64() uint64 {
 	s0, s1 := x.s[0], x.s[1]
@@ -47,21 +115,95 @@ func (x *XoroShiro128StarStar) Uint64() uint64 {
 	return r
 }
 
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *XoroShiro128StarStar) Prev() uint64 {
+	// undo the state update
+	t := bits.RotateLeft64(x.s[1], -37)            // c
+	s0 := bits.RotateLeft64(x.s[0]^t^(t<<16), -24) // a, b
+	s1 := t ^ s0
+
+	x.s[0], x.s[1] = s0, s1
+
+	return bits.RotateLeft64(s0*5, 7) * 9
+}
+
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
 func (x *XoroShiro128StarStar) Int63() int64 {
 	return int64(x.Uint64() & (1<<63 - 1))
 
 }
 
+// Float64 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-53 obtained from the upper 53 bits of Uint64().
+func (x *XoroShiro128StarStar) Float64() float64 {
+	return internal.Float64(x.Uint64())
+}
+
+// Float64Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-53.
+func (x *XoroShiro128StarStar) Float64Open() float64 {
+	return internal.Float64Open(x.Uint64())
+}
+
+// Float64Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^53-1).
+func (x *XoroShiro128StarStar) Float64Closed() float64 {
+	return internal.Float64Closed(x.Uint64())
+}
+
+// Float32 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-24 obtained from the upper 24 bits of Uint64().
+func (x *XoroShiro128StarStar) Float32() float32 {
+	return internal.Float32(x.Uint64())
+}
+
+// Float32Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-24.
+func (x *XoroShiro128StarStar) Float32Open() float32 {
+	return internal.Float32Open(x.Uint64())
+}
+
+// Float32Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^24-1).
+func (x *XoroShiro128StarStar) Float32Closed() float32 {
+	return internal.Float32Closed(x.Uint64())
+}
+
 // Jump it is equivalent to 2^64 calls to Uint64().
 func (x *XoroShiro128StarStar) Jump() {
+	x.jump(jumpPoly)
+}
+
+// JumpN it is equivalent to n calls to Jump(), that is n*2^64 calls to Uint64().
+// For a large n the jump polynomial is raised to the n-th power, with O(log n) multiplications.
+func (x *XoroShiro128StarStar) JumpN(n uint64) {
+	if n < internal.SequentialJumps {
+		for ; n > 0; n-- {
+			x.Jump()
+		}
+		return
+	}
+	x.jump(advancer.PowPoly(jumpPoly, n))
+}
+
+// LongJump it is equivalent to 2^96 calls to Uint64().
+func (x *XoroShiro128StarStar) LongJump() {
+	x.jump(longJumpPoly)
+}
+
+// Advance it is equivalent to n calls to Uint64().
+func (x *XoroShiro128StarStar) Advance(n uint64) {
+	x.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig it is equivalent to n calls to Uint64(). The jump is computed modulo
+// the period of the generator, 2^128 - 1, so a negative n moves the generator backward.
+func (x *XoroShiro128StarStar) AdvanceBig(n *big.Int) {
+	x.jump(advancer.JumpPoly(n))
+}
+
+// jump moves the state ahead as stated by the given jump polynomial.
+func (x *XoroShiro128StarStar) jump(poly []uint64) {
 	var s0, s1 uint64
 	var b uint64
-	jump := []uint64{0xdf900294d8f554a5, 0x170865df4b3201fc}
 
-	for i := 0; i < len(jump); i++ {
+	for i := 0; i < len(poly); i++ {
 		for b = 0; b < 64; b++ {
-			if jump[i]&(uint64(1)<<b) != 0 {
+			if poly[i]&(uint64(1)<<b) != 0 {
 				s1 ^= x.s[1]
 				s0 ^= x.s[0]
 			}
@@ -72,3 +214,37 @@ func (x *XoroShiro128StarStar) Jump() {
 	x.s[1] = s1
 	x.s[0] = s0
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoded state
+// holds the version of the encoding and the name of the algorithm.
+func (x *XoroShiro128StarStar) MarshalBinary() ([]byte, error) {
+	return codec.MarshalBinary(x.s[:], 0), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, it returns an error
+// if data isn't a valid XoroShiro128StarStar state.
+func (x *XoroShiro128StarStar) UnmarshalBinary(data []byte) error {
+	var s [2]uint64
+	if _, err := codec.UnmarshalBinary(data, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so the state can be
+// embedded in JSON documents. The encoded state starts with the name of the algorithm.
+func (x *XoroShiro128StarStar) MarshalText() ([]byte, error) {
+	return codec.MarshalText(x.s[:], 0), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it returns an error
+// if text isn't a valid XoroShiro128StarStar state.
+func (x *XoroShiro128StarStar) UnmarshalText(text []byte) error {
+	var s [2]uint64
+	if _, err := codec.UnmarshalText(text, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
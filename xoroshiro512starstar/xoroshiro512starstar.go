@@ -2,11 +2,39 @@
 package xoroshiro512starstar
 
 import (
+	"math/big"
 	"math/bits"
 
 	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/seedseq"
 )
 
+var (
+	// jumpPoly "const" for Jump function
+	jumpPoly = []uint64{
+		0x33ed89b6e7a353f9, 0x760083d7955323be, 0x2837f2fbb5f22fae, 0x4b8c5674d309511c,
+		0xb11ac47a7ba28c25, 0xf1be7667092bcc1c, 0x53851efdb6df0aaf, 0x1ebbc8b23eaf25db,
+	}
+
+	// longJumpPoly "const" for LongJump function
+	longJumpPoly = []uint64{
+		0x11467fef8f921d28, 0xa2a819f2e79c8ea8, 0xa8299fc284b3959a, 0xb4d347340ca63ee1,
+		0x1cb0940bedbff6ce, 0xd956c5c4fa1f8e17, 0x915e38fd4eda93bc, 0x5b3ccdfa5d7daca5,
+	}
+)
+
+// advancer computes the jump polynomials used by XoroShiro512StarStar Advance.
+var advancer = internal.NewAdvancer(512, func() func() uint64 {
+	x := NewSource(1)
+	return func() uint64 {
+		x.Uint64()
+		return x.s[0]
+	}
+})
+
+// codec describes the XoroShiro512StarStar state for the encoding functions.
+var codec = internal.Codec{Name: "xoroshiro512**", Words: 8}
+
 // XoroShiro512StarStar holds the state required by XoroShiro512StarStar generator
 type XoroShiro512StarStar struct {
 	// The state must be seeded with a nonzero value. Require 2 64-bit unsigned values.
@@ -33,6 +61,52 @@ func (x *XoroShiro512StarStar) Seed(seed int64) {
 	}
 }
 
+// NewSourceFromKey return a new XoroShiro512StarStar random number generator, with the internal state derived from key.
+func NewSourceFromKey(key []byte) *XoroShiro512StarStar {
+	tmpxs := XoroShiro512StarStar{}
+	tmpxs.SeedBytes(key)
+	return &tmpxs
+}
+
+// SeedBytes use every bit of the provvided key (e.g. a string ID or a 256-bit key) to init XoroShiro512StarStar internal state.
+// The key-to-state expansion is stable: the same key gives always the same state. Keys up to 512 bits can give distinct states.
+func (x *XoroShiro512StarStar) SeedBytes(key []byte) {
+	internal.ExpandKey(key, x.s[:])
+}
+
+// NewSourceFromSeedSequence return a new XoroShiro512StarStar random number generator, with the internal state generated by ss.
+func NewSourceFromSeedSequence(ss *seedseq.SeedSequence) *XoroShiro512StarStar {
+	tmpxs := XoroShiro512StarStar{}
+	tmpxs.SeedFrom(ss)
+	return &tmpxs
+}
+
+// SeedFrom init XoroShiro512StarStar internal state with the first 8 words generated by ss.
+func (x *XoroShiro512StarStar) SeedFrom(ss *seedseq.SeedSequence) {
+	ss.Fill(x.s[:])
+	internal.AvoidZero(x.s[:])
+}
+
+// NewSourceFromState return a new XoroShiro512StarStar random number generator with the given raw internal state,
+// as in the reference implementation. It returns an error if the state is everywhere zero.
+func NewSourceFromState(s [8]uint64) (*XoroShiro512StarStar, error) {
+	tmpxs := XoroShiro512StarStar{}
+	if err := tmpxs.SetState(s); err != nil {
+		return nil, err
+	}
+	return &tmpxs, nil
+}
+
+// SetState sets the raw internal state of XoroShiro512StarStar, as in the reference implementation.
+// It returns an error, leaving the generator unchanged, if the state is everywhere zero.
+func (x *XoroShiro512StarStar) SetState(s [8]uint64) error {
+	if internal.IsZero(s[:]) {
+		return internal.ErrZeroState
+	}
+	x.s = s
+	return nil
+}
+
 // Uint64 returns the next pseudo random number generated, before start you must provvide seed.
 func (x *XoroShiro512StarStar) Uint64() uint64 {
 	// Yeah, I know that I can use an array, but the Go compiler isn't smart as gcc, the generate code are slower.
@@ -44,28 +118,107 @@ func (x *XoroShiro512StarStar) Uint64() uint64 {
 	x.s[3] = s3 ^ s4
 	x.s[4] = s4 ^ s5 ^ s1
 	x.s[5] = s5 ^ s1
-	x.s[6] = (s1 << 11) ^ s6 ^ ^s7 ^ s3
+	x.s[6] = (s1 << 11) ^ s6 ^ s7 ^ s3
 	x.s[7] = bits.RotateLeft64(s7^s3, 21)
 
 	return bits.RotateLeft64(s1*5, 7) * 9
 }
 
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *XoroShiro512StarStar) Prev() uint64 {
+	// undo the state update
+	t := bits.RotateLeft64(x.s[7], -21) // s7 ^ s3
+	s1 := x.s[1] ^ x.s[2]
+	s6 := x.s[6] ^ (s1 << 11) ^ t
+	s0 := x.s[0] ^ s6
+	s2 := x.s[2] ^ s0
+	s5 := x.s[5] ^ s1
+	s4 := x.s[4] ^ x.s[5]
+	s3 := x.s[3] ^ s4
+	s7 := t ^ s3
+
+	x.s = [8]uint64{s0, s1, s2, s3, s4, s5, s6, s7}
+
+	return bits.RotateLeft64(s1*5, 7) * 9
+}
+
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
 func (x *XoroShiro512StarStar) Int63() int64 {
 	return int64(x.Uint64() & (1<<63 - 1))
 
 }
 
+// Float64 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-53 obtained from the upper 53 bits of Uint64().
+func (x *XoroShiro512StarStar) Float64() float64 {
+	return internal.Float64(x.Uint64())
+}
+
+// Float64Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-53.
+func (x *XoroShiro512StarStar) Float64Open() float64 {
+	return internal.Float64Open(x.Uint64())
+}
+
+// Float64Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^53-1).
+func (x *XoroShiro512StarStar) Float64Closed() float64 {
+	return internal.Float64Closed(x.Uint64())
+}
+
+// Float32 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-24 obtained from the upper 24 bits of Uint64().
+func (x *XoroShiro512StarStar) Float32() float32 {
+	return internal.Float32(x.Uint64())
+}
+
+// Float32Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-24.
+func (x *XoroShiro512StarStar) Float32Open() float32 {
+	return internal.Float32Open(x.Uint64())
+}
+
+// Float32Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^24-1).
+func (x *XoroShiro512StarStar) Float32Closed() float32 {
+	return internal.Float32Closed(x.Uint64())
+}
+
 // Jump it is equivalent to 2^256 calls to Uint64().
 func (x *XoroShiro512StarStar) Jump() {
+	x.jump(jumpPoly)
+}
+
+// JumpN it is equivalent to n calls to Jump(), that is n*2^256 calls to Uint64().
+// For a large n the jump polynomial is raised to the n-th power, with O(log n) multiplications.
+func (x *XoroShiro512StarStar) JumpN(n uint64) {
+	if n < internal.SequentialJumps {
+		for ; n > 0; n-- {
+			x.Jump()
+		}
+		return
+	}
+	x.jump(advancer.PowPoly(jumpPoly, n))
+}
+
+// LongJump it is equivalent to 2^384 calls to Uint64().
+func (x *XoroShiro512StarStar) LongJump() {
+	x.jump(longJumpPoly)
+}
+
+// Advance it is equivalent to n calls to Uint64().
+func (x *XoroShiro512StarStar) Advance(n uint64) {
+	x.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig it is equivalent to n calls to Uint64(). The jump is computed modulo
+// the period of the generator, 2^512 - 1, so a negative n moves the generator backward.
+func (x *XoroShiro512StarStar) AdvanceBig(n *big.Int) {
+	x.jump(advancer.JumpPoly(n))
+}
+
+// jump moves the state ahead as stated by the given jump polynomial.
+func (x *XoroShiro512StarStar) jump(poly []uint64) {
 	var s [8]uint64
 	var b uint64
-	jump := []uint64{0x33ed89b6e7a353f9, 0x760083d7955323be, 0x2837f2fbb5f22fae, 0x4b8c5674d309511c,
-		0xb11ac47a7ba28c25, 0xf1be7667092bcc1c, 0x53851efdb6df0aaf, 0x1ebbc8b23eaf25db}
 
-	for i := 0; i < len(jump); i++ {
+	for i := 0; i < len(poly); i++ {
 		for b = 0; b < 64; b++ {
-			if jump[i]&(uint64(1)<<b) != 0 {
+			if poly[i]&(uint64(1)<<b) != 0 {
 				s[7] ^= x.s[7]
 				s[6] ^= x.s[6]
 				s[5] ^= x.s[5]
@@ -80,3 +233,37 @@ func (x *XoroShiro512StarStar) Jump() {
 	}
 	x.s = s
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoded state
+// holds the version of the encoding and the name of the algorithm.
+func (x *XoroShiro512StarStar) MarshalBinary() ([]byte, error) {
+	return codec.MarshalBinary(x.s[:], 0), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, it returns an error
+// if data isn't a valid XoroShiro512StarStar state.
+func (x *XoroShiro512StarStar) UnmarshalBinary(data []byte) error {
+	var s [8]uint64
+	if _, err := codec.UnmarshalBinary(data, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so the state can be
+// embedded in JSON documents. The encoded state starts with the name of the algorithm.
+func (x *XoroShiro512StarStar) MarshalText() ([]byte, error) {
+	return codec.MarshalText(x.s[:], 0), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it returns an error
+// if text isn't a valid XoroShiro512StarStar state.
+func (x *XoroShiro512StarStar) UnmarshalText(text []byte) error {
+	var s [8]uint64
+	if _, err := codec.UnmarshalText(text, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
@@ -1,6 +1,8 @@
 // Package xorshift define interfaces implemented by the generators
 package xorshift
 
+import "math/big"
+
 // The interfaces defined here, can be used to simplify your code if you want to switch from
 // one generator to another.
 
@@ -15,3 +17,35 @@ type XorShiftExt interface {
 	XorShift
 	Jump()
 }
+
+// XorShiftLongJumper optional functions, the xoroshiro... sub packages implements even this interface.
+// Jump and LongJump can be used to build a two-level hierarchy of non-overlapping streams,
+// e.g. LongJump() once per computing node and Jump() once per thread of the node.
+type XorShiftLongJumper interface {
+	XorShiftExt
+	LongJump()
+}
+
+// XorShiftAdvancer optional functions, the sub packages with a linear engine (all but splitmix64)
+// implements even this interface. Advance moves the generator ahead of an arbitrary number of steps,
+// using x^n mod the characteristic polynomial of the generator, so it's fast even for huge n.
+type XorShiftAdvancer interface {
+	XorShift
+	Advance(n uint64)
+	AdvanceBig(n *big.Int)
+}
+
+// XorShiftReverser optional function, all the sub packages implements even this interface.
+// Prev undoes the last call to Uint64(), returning the value produced by that call,
+// so the sequence of generated values can be walked backward.
+type XorShiftReverser interface {
+	XorShift
+	Prev() uint64
+}
+
+// XorShiftStreamJumper optional function, the sub packages that implements XorShiftExt implements even this interface.
+// JumpN(n) is equivalent to n calls to Jump(), but for a large n it takes O(log n) time, so it's fast even for huge n.
+type XorShiftStreamJumper interface {
+	XorShiftExt
+	JumpN(n uint64)
+}
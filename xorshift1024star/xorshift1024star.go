@@ -4,9 +4,24 @@ Package xorshift1024star it's like other xorshift*, generators, but with a 2^102
 package xorshift1024star
 
 import (
+	"math/big"
+
 	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/seedseq"
 )
 
+// advancer computes the jump polynomials used by XorShift1024Star Advance.
+var advancer = internal.NewAdvancer(1024, func() func() uint64 {
+	x := NewSource(1)
+	return func() uint64 {
+		x.Uint64()
+		return x.s[x.p]
+	}
+})
+
+// codec describes the XorShift1024Star state for the encoding functions.
+var codec = internal.Codec{Name: "xorshift1024*", Words: 16, Position: true}
+
 // XorShift1024Star holds the state required by XorShift1024Star generator.
 type XorShift1024Star struct {
 	// The state must be seeded with a nonzero value. Require 16 64-bit unsigned values.
@@ -35,6 +50,56 @@ func (x *XorShift1024Star) Seed(seed int64) {
 	x.p = 0
 }
 
+// NewSourceFromKey return a new XorShift1024Star random number generator, with the internal state derived from key.
+func NewSourceFromKey(key []byte) *XorShift1024Star {
+	tmpxs := XorShift1024Star{}
+	tmpxs.SeedBytes(key)
+	return &tmpxs
+}
+
+// SeedBytes use every bit of the provvided key (e.g. a string ID or a 256-bit key) to init XorShift1024Star internal state.
+// The key-to-state expansion is stable: the same key gives always the same state. Keys up to 1024 bits can give distinct states.
+func (x *XorShift1024Star) SeedBytes(key []byte) {
+	internal.ExpandKey(key, x.s[:])
+	x.p = 0
+}
+
+// NewSourceFromSeedSequence return a new XorShift1024Star random number generator, with the internal state generated by ss.
+func NewSourceFromSeedSequence(ss *seedseq.SeedSequence) *XorShift1024Star {
+	tmpxs := XorShift1024Star{}
+	tmpxs.SeedFrom(ss)
+	return &tmpxs
+}
+
+// SeedFrom init XorShift1024Star internal state with the first 16 words generated by ss.
+func (x *XorShift1024Star) SeedFrom(ss *seedseq.SeedSequence) {
+	ss.Fill(x.s[:])
+	internal.AvoidZero(x.s[:])
+	x.p = 0
+}
+
+// NewSourceFromState return a new XorShift1024Star random number generator with the given raw internal state,
+// as in the reference implementation. It returns an error if the state is everywhere zero.
+func NewSourceFromState(s [16]uint64) (*XorShift1024Star, error) {
+	tmpxs := XorShift1024Star{}
+	if err := tmpxs.SetState(s); err != nil {
+		return nil, err
+	}
+	return &tmpxs, nil
+}
+
+// SetState sets the raw internal state of XorShift1024Star, as in the reference implementation
+// (s[0] is the first word used by the generator).
+// It returns an error, leaving the generator unchanged, if the state is everywhere zero.
+func (x *XorShift1024Star) SetState(s [16]uint64) error {
+	if internal.IsZero(s[:]) {
+		return internal.ErrZeroState
+	}
+	x.s = s
+	x.p = 0
+	return nil
+}
+
 // Uint64 returns the next pseudo random number generated, before start you must provvide seed.
 func (x *XorShift1024Star) Uint64() uint64 {
 	xpnew := (x.p + 1) & 15
@@ -51,19 +116,91 @@ func (x *XorShift1024Star) Uint64() uint64 {
 	return tmp * uint64(1181783497276652981)
 }
 
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *XorShift1024Star) Prev() uint64 {
+	r := x.s[x.p] * uint64(1181783497276652981)
+
+	// undo the state update
+	xpold := (x.p - 1) & 15
+	s0 := x.s[xpold]
+	s1 := internal.UnshiftRight(x.s[x.p]^s0^(s0>>30), 11) // b, c
+	x.s[x.p] = internal.UnshiftLeft(s1, 31)               // a
+	x.p = xpold
+
+	return r
+}
+
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
 func (x *XorShift1024Star) Int63() int64 {
 	return int64(x.Uint64() & (1<<63 - 1))
 }
 
+// Float64 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-53 obtained from the upper 53 bits of Uint64().
+func (x *XorShift1024Star) Float64() float64 {
+	return internal.Float64(x.Uint64())
+}
+
+// Float64Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-53.
+func (x *XorShift1024Star) Float64Open() float64 {
+	return internal.Float64Open(x.Uint64())
+}
+
+// Float64Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^53-1).
+func (x *XorShift1024Star) Float64Closed() float64 {
+	return internal.Float64Closed(x.Uint64())
+}
+
+// Float32 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-24 obtained from the upper 24 bits of Uint64().
+func (x *XorShift1024Star) Float32() float32 {
+	return internal.Float32(x.Uint64())
+}
+
+// Float32Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-24.
+func (x *XorShift1024Star) Float32Open() float32 {
+	return internal.Float32Open(x.Uint64())
+}
+
+// Float32Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^24-1).
+func (x *XorShift1024Star) Float32Closed() float32 {
+	return internal.Float32Closed(x.Uint64())
+}
+
 // Jump function for the generator. It is equivalent to 2^512 calls to  Uint64()
 func (x *XorShift1024Star) Jump() {
+	x.jump(internal.Jump1024)
+}
+
+// JumpN it is equivalent to n calls to Jump(), that is n*2^512 calls to Uint64().
+// For a large n the jump polynomial is raised to the n-th power, with O(log n) multiplications.
+func (x *XorShift1024Star) JumpN(n uint64) {
+	if n < internal.SequentialJumps {
+		for ; n > 0; n-- {
+			x.Jump()
+		}
+		return
+	}
+	x.jump(advancer.PowPoly(internal.Jump1024, n))
+}
+
+// Advance it is equivalent to n calls to Uint64().
+func (x *XorShift1024Star) Advance(n uint64) {
+	x.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig it is equivalent to n calls to Uint64(). The jump is computed modulo
+// the period of the generator, 2^1024 - 1, so a negative n moves the generator backward.
+func (x *XorShift1024Star) AdvanceBig(n *big.Int) {
+	x.jump(advancer.JumpPoly(n))
+}
+
+// jump moves the state ahead as stated by the given jump polynomial.
+func (x *XorShift1024Star) jump(poly []uint64) {
 	var t [16]uint64
 	var b uint64
 
-	for i := 0; i < len(internal.Jump1024); i++ {
+	for i := 0; i < len(poly); i++ {
 		for b = 0; b < 64; b++ {
-			if internal.Jump1024[i]&(uint64(1)<<b) != 0 {
+			if poly[i]&(uint64(1)<<b) != 0 {
 				for j := 0; j < 16; j++ {
 					t[j] ^= x.s[(j+x.p)&15]
 				}
@@ -76,3 +213,39 @@ func (x *XorShift1024Star) Jump() {
 		x.s[(j+x.p)&15] = t[j]
 	}
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoded state
+// holds the version of the encoding and the name of the algorithm.
+func (x *XorShift1024Star) MarshalBinary() ([]byte, error) {
+	return codec.MarshalBinary(x.s[:], x.p), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, it returns an error
+// if data isn't a valid XorShift1024Star state.
+func (x *XorShift1024Star) UnmarshalBinary(data []byte) error {
+	var s [16]uint64
+	p, err := codec.UnmarshalBinary(data, s[:])
+	if err != nil {
+		return err
+	}
+	x.s, x.p = s, p
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so the state can be
+// embedded in JSON documents. The encoded state starts with the name of the algorithm.
+func (x *XorShift1024Star) MarshalText() ([]byte, error) {
+	return codec.MarshalText(x.s[:], x.p), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it returns an error
+// if text isn't a valid XorShift1024Star state.
+func (x *XorShift1024Star) UnmarshalText(text []byte) error {
+	var s [16]uint64
+	p, err := codec.UnmarshalText(text, s[:])
+	if err != nil {
+		return err
+	}
+	x.s, x.p = s, p
+	return nil
+}
@@ -5,9 +5,24 @@ It use a 128 bit internal state.
 package xorshift128plus
 
 import (
+	"math/big"
+
 	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/seedseq"
 )
 
+// advancer computes the jump polynomials used by XorShift128Plus Advance.
+var advancer = internal.NewAdvancer(128, func() func() uint64 {
+	x := NewSource(1)
+	return func() uint64 {
+		x.Uint64()
+		return x.s[0]
+	}
+})
+
+// codec describes the XorShift128Plus state for the encoding functions.
+var codec = internal.Codec{Name: "xorshift128+", Words: 2}
+
 // XorShift128Plus holds the state required by XorShift128Plus generator.
 type XorShift128Plus struct {
 	// The state must be seeded with a nonzero value. Require 2 64-bit unsigned values.
@@ -34,6 +49,52 @@ func (x *XorShift128Plus) Seed(seed int64) {
 	}
 }
 
+// NewSourceFromKey return a new XorShift128Plus random number generator, with the internal state derived from key.
+func NewSourceFromKey(key []byte) *XorShift128Plus {
+	tmpxs := XorShift128Plus{}
+	tmpxs.SeedBytes(key)
+	return &tmpxs
+}
+
+// SeedBytes use every bit of the provvided key (e.g. a string ID or a 256-bit key) to init XorShift128Plus internal state.
+// The key-to-state expansion is stable: the same key gives always the same state. Keys up to 128 bits can give distinct states.
+func (x *XorShift128Plus) SeedBytes(key []byte) {
+	internal.ExpandKey(key, x.s[:])
+}
+
+// NewSourceFromSeedSequence return a new XorShift128Plus random number generator, with the internal state generated by ss.
+func NewSourceFromSeedSequence(ss *seedseq.SeedSequence) *XorShift128Plus {
+	tmpxs := XorShift128Plus{}
+	tmpxs.SeedFrom(ss)
+	return &tmpxs
+}
+
+// SeedFrom init XorShift128Plus internal state with the first 2 words generated by ss.
+func (x *XorShift128Plus) SeedFrom(ss *seedseq.SeedSequence) {
+	ss.Fill(x.s[:])
+	internal.AvoidZero(x.s[:])
+}
+
+// NewSourceFromState return a new XorShift128Plus random number generator with the given raw internal state,
+// as in the reference implementation. It returns an error if the state is everywhere zero.
+func NewSourceFromState(s [2]uint64) (*XorShift128Plus, error) {
+	tmpxs := XorShift128Plus{}
+	if err := tmpxs.SetState(s); err != nil {
+		return nil, err
+	}
+	return &tmpxs, nil
+}
+
+// SetState sets the raw internal state of XorShift128Plus, as in the reference implementation.
+// It returns an error, leaving the generator unchanged, if the state is everywhere zero.
+func (x *XorShift128Plus) SetState(s [2]uint64) error {
+	if internal.IsZero(s[:]) {
+		return internal.ErrZeroState
+	}
+	x.s = s
+	return nil
+}
+
 // Uint64 returns the next pseudo random number generated, before start you must provvide seed.
 func (x *XorShift128Plus) Uint64() uint64 {
 	s1 := x.s[0]
@@ -50,19 +111,90 @@ func (x *XorShift128Plus) Uint64() uint64 {
 	return s1 + s0 // b, c
 }
 
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *XorShift128Plus) Prev() uint64 {
+	s0 := x.s[0]
+	r := x.s[1] + s0
+
+	// undo the state update
+	s1 := internal.UnshiftRight(x.s[1]^s0^(s0>>5), 18) // b, c
+	x.s[0] = internal.UnshiftLeft(s1, 23)              // a
+	x.s[1] = s0
+
+	return r
+}
+
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
 func (x *XorShift128Plus) Int63() int64 {
 	return int64(x.Uint64() & (1<<63 - 1))
 }
 
+// Float64 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-53 obtained from the upper 53 bits of Uint64().
+func (x *XorShift128Plus) Float64() float64 {
+	return internal.Float64(x.Uint64())
+}
+
+// Float64Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-53.
+func (x *XorShift128Plus) Float64Open() float64 {
+	return internal.Float64Open(x.Uint64())
+}
+
+// Float64Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^53-1).
+func (x *XorShift128Plus) Float64Closed() float64 {
+	return internal.Float64Closed(x.Uint64())
+}
+
+// Float32 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-24 obtained from the upper 24 bits of Uint64().
+func (x *XorShift128Plus) Float32() float32 {
+	return internal.Float32(x.Uint64())
+}
+
+// Float32Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-24.
+func (x *XorShift128Plus) Float32Open() float32 {
+	return internal.Float32Open(x.Uint64())
+}
+
+// Float32Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^24-1).
+func (x *XorShift128Plus) Float32Closed() float32 {
+	return internal.Float32Closed(x.Uint64())
+}
+
 // Jump it is equivalent to 2^64 calls to Uint64().
 func (x *XorShift128Plus) Jump() {
+	x.jump(internal.Jump128)
+}
+
+// JumpN it is equivalent to n calls to Jump(), that is n*2^64 calls to Uint64().
+// For a large n the jump polynomial is raised to the n-th power, with O(log n) multiplications.
+func (x *XorShift128Plus) JumpN(n uint64) {
+	if n < internal.SequentialJumps {
+		for ; n > 0; n-- {
+			x.Jump()
+		}
+		return
+	}
+	x.jump(advancer.PowPoly(internal.Jump128, n))
+}
+
+// Advance it is equivalent to n calls to Uint64().
+func (x *XorShift128Plus) Advance(n uint64) {
+	x.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig it is equivalent to n calls to Uint64(). The jump is computed modulo
+// the period of the generator, 2^128 - 1, so a negative n moves the generator backward.
+func (x *XorShift128Plus) AdvanceBig(n *big.Int) {
+	x.jump(advancer.JumpPoly(n))
+}
+
+// jump moves the state ahead as stated by the given jump polynomial.
+func (x *XorShift128Plus) jump(poly []uint64) {
 	var s0, s1 uint64 = 0, 0
 	var b uint64
 
-	for i := 0; i < len(internal.Jump128); i++ {
+	for i := 0; i < len(poly); i++ {
 		for b = 0; b < 64; b++ {
-			if internal.Jump128[i]&(uint64(1)<<b) != 0 {
+			if poly[i]&(uint64(1)<<b) != 0 {
 				s0 ^= x.s[0]
 				s1 ^= x.s[1]
 			}
@@ -73,3 +205,37 @@ func (x *XorShift128Plus) Jump() {
 	x.s[0] = s0
 	x.s[1] = s1
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoded state
+// holds the version of the encoding and the name of the algorithm.
+func (x *XorShift128Plus) MarshalBinary() ([]byte, error) {
+	return codec.MarshalBinary(x.s[:], 0), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, it returns an error
+// if data isn't a valid XorShift128Plus state.
+func (x *XorShift128Plus) UnmarshalBinary(data []byte) error {
+	var s [2]uint64
+	if _, err := codec.UnmarshalBinary(data, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so the state can be
+// embedded in JSON documents. The encoded state starts with the name of the algorithm.
+func (x *XorShift128Plus) MarshalText() ([]byte, error) {
+	return codec.MarshalText(x.s[:], 0), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it returns an error
+// if text isn't a valid XorShift128Plus state.
+func (x *XorShift128Plus) UnmarshalText(text []byte) error {
+	var s [2]uint64
+	if _, err := codec.UnmarshalText(text, s[:]); err != nil {
+		return err
+	}
+	x.s = s
+	return nil
+}
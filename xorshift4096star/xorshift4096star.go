@@ -4,9 +4,44 @@ Package xorshift4096star it's like other xorshift*, generators, but with a 2^409
 package xorshift4096star
 
 import (
+	"math/big"
+
 	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/seedseq"
 )
 
+// jumpPoly "const" for Jump function
+var jumpPoly = []uint64{
+	0x81726c183e1f2b32, 0x1d14b4ca1ccb4f83, 0xc4fa8e4804b07141, 0xd60ff82970b55da5,
+	0x9a66c0cf60970c40, 0x743a1c8ffe415090, 0x486fc0088093ca47, 0xac4220169ceca91a,
+	0xffffb0134c4d0de8, 0xfe862370f7398db9, 0x1e0c12f97aaa6997, 0xf11c70d04ae83b48,
+	0x06f6bdd08f1e98e3, 0xf5610872b815d50f, 0x8de6347c69d88e81, 0x441c4656de824551,
+	0xc1a6c2754d439778, 0xcd0c0878900e4e61, 0xcf0264f0fbac2e9a, 0x13752b3872e399ad,
+	0x0bfe48c5219e45a8, 0xdedeb2ad0c1cbb6a, 0xf602f5014fa0d762, 0xbe21bc9e563ba41c,
+	0x5ba36a81cf13e5bb, 0x36e7abdade6c5a4d, 0x9f7c353f36074299, 0xf48dcd69b5e2b892,
+	0x60d18c48ecc102bb, 0xf2159d8829b21e90, 0xf90858dc22888710, 0xe934b0fac841a566,
+	0x4261af95d0a3c787, 0x48fed20489249b2a, 0x38b3fb92a702dd5b, 0x962d3343413d5df9,
+	0x1c4a15e89b820d07, 0x86c62fe67125cd85, 0x15f5959b07478428, 0xce428e6f7f34a2c8,
+	0xfdcf54a260a1e30d, 0x89ae2298b4a68c64, 0x9e9b475801a2ba16, 0x84f76e9650413be1,
+	0xf01414094d5c8e5a, 0xbe503690c568da11, 0xc79a989b5018b1d6, 0x9fcbdaf2f8e4a9a9,
+	0x527301ba68a003d1, 0x077629e226eb6930, 0x8944b588ead2e0da, 0x3f4a47805130d14d,
+	0x5ab4260d606d5101, 0xce4fd11cefd2b498, 0xb77a820a4f03c3cf, 0x8a865d2da2f294ec,
+	0xef2f24022e77070c, 0x86b58c3752d6892c, 0xced214f46381e6aa, 0xe1d937ab2f8e8565,
+	0xd98e325ac21b919c, 0xea32c337e8f0a56a, 0x79eab3f0eaf1a242, 0xd0bbbeeae8920e6c,
+}
+
+// advancer computes the jump polynomials used by XorShift4096Star Advance.
+var advancer = internal.NewAdvancer(4096, func() func() uint64 {
+	x := NewSource(1)
+	return func() uint64 {
+		x.Uint64()
+		return x.s[x.p]
+	}
+})
+
+// codec describes the XorShift4096Star state for the encoding functions.
+var codec = internal.Codec{Name: "xorshift4096*", Words: 64, Position: true}
+
 // XorShift4096Star holds the state required by XorShift4096Star generator.
 type XorShift4096Star struct {
 	// The state must be seeded with a nonzero value. Require 64 64-bit unsigned values.
@@ -35,6 +70,56 @@ func (x *XorShift4096Star) Seed(seed int64) {
 	x.p = 0
 }
 
+// NewSourceFromKey return a new XorShift4096Star random number generator, with the internal state derived from key.
+func NewSourceFromKey(key []byte) *XorShift4096Star {
+	tmpxs := XorShift4096Star{}
+	tmpxs.SeedBytes(key)
+	return &tmpxs
+}
+
+// SeedBytes use every bit of the provvided key (e.g. a string ID or a 256-bit key) to init XorShift4096Star internal state.
+// The key-to-state expansion is stable: the same key gives always the same state. Keys up to 4096 bits can give distinct states.
+func (x *XorShift4096Star) SeedBytes(key []byte) {
+	internal.ExpandKey(key, x.s[:])
+	x.p = 0
+}
+
+// NewSourceFromSeedSequence return a new XorShift4096Star random number generator, with the internal state generated by ss.
+func NewSourceFromSeedSequence(ss *seedseq.SeedSequence) *XorShift4096Star {
+	tmpxs := XorShift4096Star{}
+	tmpxs.SeedFrom(ss)
+	return &tmpxs
+}
+
+// SeedFrom init XorShift4096Star internal state with the first 64 words generated by ss.
+func (x *XorShift4096Star) SeedFrom(ss *seedseq.SeedSequence) {
+	ss.Fill(x.s[:])
+	internal.AvoidZero(x.s[:])
+	x.p = 0
+}
+
+// NewSourceFromState return a new XorShift4096Star random number generator with the given raw internal state,
+// as in the reference implementation. It returns an error if the state is everywhere zero.
+func NewSourceFromState(s [64]uint64) (*XorShift4096Star, error) {
+	tmpxs := XorShift4096Star{}
+	if err := tmpxs.SetState(s); err != nil {
+		return nil, err
+	}
+	return &tmpxs, nil
+}
+
+// SetState sets the raw internal state of XorShift4096Star, as in the reference implementation
+// (s[0] is the first word used by the generator).
+// It returns an error, leaving the generator unchanged, if the state is everywhere zero.
+func (x *XorShift4096Star) SetState(s [64]uint64) error {
+	if internal.IsZero(s[:]) {
+		return internal.ErrZeroState
+	}
+	x.s = s
+	x.p = 0
+	return nil
+}
+
 // Uint64 returns the next pseudo random number generated, before start you must provvide seed.
 func (x *XorShift4096Star) Uint64() uint64 {
 	xpnew := (x.p + 1) & 63
@@ -54,7 +139,138 @@ func (x *XorShift4096Star) Uint64() uint64 {
 	return tmp * uint64(8372773778140471301)
 }
 
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *XorShift4096Star) Prev() uint64 {
+	r := x.s[x.p] * uint64(8372773778140471301)
+
+	// undo the state update
+	xpold := (x.p - 1) & 63
+	s0 := x.s[xpold]
+	s0 ^= s0 >> 49                              // c
+	s1 := internal.UnshiftRight(x.s[x.p]^s0, 3) // b
+	x.s[x.p] = internal.UnshiftLeft(s1, 25)     // a
+	x.p = xpold
+
+	return r
+}
+
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
 func (x *XorShift4096Star) Int63() int64 {
 	return int64(x.Uint64() & (1<<63 - 1))
 }
+
+// Float64 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-53 obtained from the upper 53 bits of Uint64().
+func (x *XorShift4096Star) Float64() float64 {
+	return internal.Float64(x.Uint64())
+}
+
+// Float64Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-53.
+func (x *XorShift4096Star) Float64Open() float64 {
+	return internal.Float64Open(x.Uint64())
+}
+
+// Float64Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^53-1).
+func (x *XorShift4096Star) Float64Closed() float64 {
+	return internal.Float64Closed(x.Uint64())
+}
+
+// Float32 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-24 obtained from the upper 24 bits of Uint64().
+func (x *XorShift4096Star) Float32() float32 {
+	return internal.Float32(x.Uint64())
+}
+
+// Float32Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-24.
+func (x *XorShift4096Star) Float32Open() float32 {
+	return internal.Float32Open(x.Uint64())
+}
+
+// Float32Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^24-1).
+func (x *XorShift4096Star) Float32Closed() float32 {
+	return internal.Float32Closed(x.Uint64())
+}
+
+// Jump function for the generator. It is equivalent to 2^2048 calls to Uint64(),
+// it can be used to generate 2^2048 non-overlapping subsequences for parallel computations.
+func (x *XorShift4096Star) Jump() {
+	x.jump(jumpPoly)
+}
+
+// JumpN it is equivalent to n calls to Jump(), that is n*2^2048 calls to Uint64().
+// For a large n the jump polynomial is raised to the n-th power, with O(log n) multiplications.
+func (x *XorShift4096Star) JumpN(n uint64) {
+	if n < internal.SequentialJumps {
+		for ; n > 0; n-- {
+			x.Jump()
+		}
+		return
+	}
+	x.jump(advancer.PowPoly(jumpPoly, n))
+}
+
+// Advance it is equivalent to n calls to Uint64().
+func (x *XorShift4096Star) Advance(n uint64) {
+	x.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig it is equivalent to n calls to Uint64(). The jump is computed modulo
+// the period of the generator, 2^4096 - 1, so a negative n moves the generator backward.
+func (x *XorShift4096Star) AdvanceBig(n *big.Int) {
+	x.jump(advancer.JumpPoly(n))
+}
+
+// jump moves the state ahead as stated by the given jump polynomial.
+func (x *XorShift4096Star) jump(poly []uint64) {
+	var t [64]uint64
+	var b uint64
+
+	for i := 0; i < len(poly); i++ {
+		for b = 0; b < 64; b++ {
+			if poly[i]&(uint64(1)<<b) != 0 {
+				for j := 0; j < 64; j++ {
+					t[j] ^= x.s[(j+x.p)&63]
+				}
+			}
+			x.Uint64()
+		}
+	}
+
+	for j := 0; j < 64; j++ {
+		x.s[(j+x.p)&63] = t[j]
+	}
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoded state
+// holds the version of the encoding and the name of the algorithm.
+func (x *XorShift4096Star) MarshalBinary() ([]byte, error) {
+	return codec.MarshalBinary(x.s[:], x.p), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, it returns an error
+// if data isn't a valid XorShift4096Star state.
+func (x *XorShift4096Star) UnmarshalBinary(data []byte) error {
+	var s [64]uint64
+	p, err := codec.UnmarshalBinary(data, s[:])
+	if err != nil {
+		return err
+	}
+	x.s, x.p = s, p
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so the state can be
+// embedded in JSON documents. The encoded state starts with the name of the algorithm.
+func (x *XorShift4096Star) MarshalText() ([]byte, error) {
+	return codec.MarshalText(x.s[:], x.p), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it returns an error
+// if text isn't a valid XorShift4096Star state.
+func (x *XorShift4096Star) UnmarshalText(text []byte) error {
+	var s [64]uint64
+	p, err := codec.UnmarshalText(text, s[:])
+	if err != nil {
+		return err
+	}
+	x.s, x.p = s, p
+	return nil
+}
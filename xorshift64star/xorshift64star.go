@@ -3,21 +3,100 @@ Package xorshift64star it's like other xorshift*, generators, but with a 2^64 -
 */
 package xorshift64star
 
+import (
+	"math/big"
+
+	"github.com/vpxyz/xorshift/internal"
+	"github.com/vpxyz/xorshift/seedseq"
+)
+
+// jumpPoly "const" for Jump function
+var jumpPoly = []uint64{0xbbd5e1c3a495e3e0}
+
+// advancer computes the jump polynomials used by XorShift64Star Advance.
+var advancer = internal.NewAdvancer(64, func() func() uint64 {
+	x := NewSource(1)
+	return func() uint64 {
+		x.Uint64()
+		return x.s
+	}
+})
+
+// codec describes the XorShift64Star state for the encoding functions.
+var codec = internal.Codec{Name: "xorshift64*", Words: 1}
+
 // XorShift64Star hold the state required by the XorShift64Star generator.
 type XorShift64Star struct {
 	s uint64 // The state must be seeded with a nonzero value. Require a 64-bit unsigned values.
 }
 
+// zeroSeed replaces the zero seed, that would make the generator stuck at zero forever.
+const zeroSeed = 0x9E3779B97F4A7C15
+
 // NewSource return a new XorShift64Star random number generator.
 func NewSource(seed int64) *XorShift64Star {
 	tmpxs := XorShift64Star{}
-	tmpxs.s = uint64(seed)
+	tmpxs.Seed(seed)
 	return &tmpxs
 }
 
+// NewSourceFromState return a new XorShift64Star random number generator with the given raw internal state,
+// as in the reference implementation. It returns an error if the state is zero.
+func NewSourceFromState(s uint64) (*XorShift64Star, error) {
+	tmpxs := XorShift64Star{}
+	if err := tmpxs.SetState(s); err != nil {
+		return nil, err
+	}
+	return &tmpxs, nil
+}
+
 // Seed use the provvided seed value to init XorShift64Star internal state.
+// The seed is used as is, but zero, that is replaced by a fixed nonzero value.
 func (x *XorShift64Star) Seed(seed int64) {
 	x.s = uint64(seed)
+	if x.s == 0 {
+		x.s = zeroSeed
+	}
+}
+
+// NewSourceFromKey return a new XorShift64Star random number generator, with the internal state derived from key.
+func NewSourceFromKey(key []byte) *XorShift64Star {
+	tmpxs := XorShift64Star{}
+	tmpxs.SeedBytes(key)
+	return &tmpxs
+}
+
+// SeedBytes use every bit of the provvided key (e.g. a string ID or a 256-bit key) to init XorShift64Star internal state.
+// The key-to-state expansion is stable: the same key gives always the same state. The key is hashed down to the 64 bits of the state.
+func (x *XorShift64Star) SeedBytes(key []byte) {
+	var s [1]uint64
+	internal.ExpandKey(key, s[:])
+	x.s = s[0]
+}
+
+// NewSourceFromSeedSequence return a new XorShift64Star random number generator, with the internal state generated by ss.
+func NewSourceFromSeedSequence(ss *seedseq.SeedSequence) *XorShift64Star {
+	tmpxs := XorShift64Star{}
+	tmpxs.SeedFrom(ss)
+	return &tmpxs
+}
+
+// SeedFrom init XorShift64Star internal state with the first 1 word generated by ss.
+func (x *XorShift64Star) SeedFrom(ss *seedseq.SeedSequence) {
+	var s [1]uint64
+	ss.Fill(s[:])
+	internal.AvoidZero(s[:])
+	x.s = s[0]
+}
+
+// SetState sets the raw internal state of XorShift64Star, as in the reference implementation.
+// It returns an error, leaving the generator unchanged, if the state is zero.
+func (x *XorShift64Star) SetState(s uint64) error {
+	if s == 0 {
+		return internal.ErrZeroState
+	}
+	x.s = s
+	return nil
 }
 
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
@@ -26,12 +105,133 @@ func (x *XorShift64Star) Int63() int64 {
 
 }
 
+// Float64 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-53 obtained from the upper 53 bits of Uint64().
+func (x *XorShift64Star) Float64() float64 {
+	return internal.Float64(x.Uint64())
+}
+
+// Float64Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-53.
+func (x *XorShift64Star) Float64Open() float64 {
+	return internal.Float64Open(x.Uint64())
+}
+
+// Float64Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^53-1).
+func (x *XorShift64Star) Float64Closed() float64 {
+	return internal.Float64Closed(x.Uint64())
+}
+
+// Float32 returns a pseudo-random number in [0.0,1.0), a multiple of 2^-24 obtained from the upper 24 bits of Uint64().
+func (x *XorShift64Star) Float32() float32 {
+	return internal.Float32(x.Uint64())
+}
+
+// Float32Open returns a pseudo-random number in the open interval (0.0,1.0), an odd multiple of 2^-24.
+func (x *XorShift64Star) Float32Open() float32 {
+	return internal.Float32Open(x.Uint64())
+}
+
+// Float32Closed returns a pseudo-random number in the closed interval [0.0,1.0], a multiple of 1/(2^24-1).
+func (x *XorShift64Star) Float32Closed() float32 {
+	return internal.Float32Closed(x.Uint64())
+}
+
 // Uint64 returns the next pseudo random number generated, before start you must provvide one 64 unsigned bit seed.
 func (x *XorShift64Star) Uint64() uint64 {
+	x.s ^= x.s >> 12 // a
+	x.s ^= x.s << 25 // b
+	x.s ^= x.s >> 27 // c
+
+	return x.s * uint64(2685821657736338717)
+}
+
+// Prev undoes the last call to Uint64(), moving the generator one step backward, and returns the value produced by that call.
+func (x *XorShift64Star) Prev() uint64 {
 	r := x.s * uint64(2685821657736338717)
-	x.s ^= x.s >> 12
-	x.s ^= x.s << 25
-	x.s ^= x.s >> 27
+
+	// undo the state update
+	x.s = internal.UnshiftRight(x.s, 27) // c
+	x.s = internal.UnshiftLeft(x.s, 25)  // b
+	x.s = internal.UnshiftRight(x.s, 12) // a
 
 	return r
 }
+
+// Jump it is equivalent to 2^32 calls to Uint64(), it can be used to generate
+// 2^32 non-overlapping subsequences of 2^32 values for parallel computations.
+func (x *XorShift64Star) Jump() {
+	x.jump(jumpPoly)
+}
+
+// JumpN it is equivalent to n calls to Jump(), that is n*2^32 calls to Uint64().
+// For a large n the jump polynomial is raised to the n-th power, with O(log n) multiplications.
+func (x *XorShift64Star) JumpN(n uint64) {
+	if n < internal.SequentialJumps {
+		for ; n > 0; n-- {
+			x.Jump()
+		}
+		return
+	}
+	x.jump(advancer.PowPoly(jumpPoly, n))
+}
+
+// Advance it is equivalent to n calls to Uint64().
+func (x *XorShift64Star) Advance(n uint64) {
+	x.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig it is equivalent to n calls to Uint64(). The jump is computed modulo
+// the period of the generator, 2^64 - 1, so a negative n moves the generator backward.
+func (x *XorShift64Star) AdvanceBig(n *big.Int) {
+	x.jump(advancer.JumpPoly(n))
+}
+
+// jump moves the state ahead as stated by the given jump polynomial.
+func (x *XorShift64Star) jump(poly []uint64) {
+	var s uint64
+	var b uint64
+
+	for i := 0; i < len(poly); i++ {
+		for b = 0; b < 64; b++ {
+			if poly[i]&(uint64(1)<<b) != 0 {
+				s ^= x.s
+			}
+			x.Uint64()
+		}
+	}
+
+	x.s = s
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoded state
+// holds the version of the encoding and the name of the algorithm.
+func (x *XorShift64Star) MarshalBinary() ([]byte, error) {
+	return codec.MarshalBinary([]uint64{x.s}, 0), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, it returns an error
+// if data isn't a valid XorShift64Star state.
+func (x *XorShift64Star) UnmarshalBinary(data []byte) error {
+	var s [1]uint64
+	if _, err := codec.UnmarshalBinary(data, s[:]); err != nil {
+		return err
+	}
+	x.s = s[0]
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so the state can be
+// embedded in JSON documents. The encoded state starts with the name of the algorithm.
+func (x *XorShift64Star) MarshalText() ([]byte, error) {
+	return codec.MarshalText([]uint64{x.s}, 0), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it returns an error
+// if text isn't a valid XorShift64Star state.
+func (x *XorShift64Star) UnmarshalText(text []byte) error {
+	var s [1]uint64
+	if _, err := codec.UnmarshalText(text, s[:]); err != nil {
+		return err
+	}
+	x.s = s[0]
+	return nil
+}
@@ -103,6 +103,15 @@ func BenchmarkXoroShiro128PlusAsRand64(b *testing.B) {
 	}
 }
 
+func BenchmarkXoroShiro128PlusLegacySource64(b *testing.B) {
+	xs := xoroshiro128plus.NewLegacySource(SEED)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = xs.Uint64()
+	}
+}
+
 func BenchmarkXoroShiro128StarStarSource64(b *testing.B) {
 	xs := xoroshiro128starstar.NewSource(SEED)
 	b.ReportAllocs()